@@ -1,8 +1,13 @@
 package pokedex
 
+//go:generate go run ./cmd/pokedex-gen
+
 import (
 	"errors"
 	"strconv"
+
+	"github.com/mdcurran/pokedex/internal/defaults"
+	"github.com/mdcurran/pokedex/iterator"
 )
 
 var (
@@ -17,10 +22,10 @@ type GetRequest struct {
 
 func (r *GetRequest) GetResource() (string, error) {
 	if r.ID == 0 && r.Name == "" {
-		return "", NewError(ErrMissingResources.Error(), CodeInvalidArgs, nil)
+		return "", NewError(KindInvalidArgs, ErrMissingResources, nil)
 	}
 	if r.ID != 0 && r.Name != "" {
-		return "", NewError(ErrMultipleResources.Error(), CodeInvalidArgs, nil)
+		return "", NewError(KindInvalidArgs, ErrMultipleResources, nil)
 	}
 
 	if r.ID != 0 {
@@ -30,5 +35,49 @@ func (r *GetRequest) GetResource() (string, error) {
 }
 
 type ListRequest struct {
+	// PageSize is the number of resources fetched per page. Defaults to 20.
 	PageSize uint
+	// Offset skips that many resources at the start of the list, for example
+	// 151 to start from the second generation of Pokémon.
+	Offset uint
+	// Limit is the maximum number of resources listed, starting from Offset.
+	// If zero, the list continues until its end.
+	Limit uint
+	// PartialResults determines whether a page is still returned when some of
+	// its resources fail to be fetched. If true, the successfully fetched
+	// resources are returned alongside a *PageError, and the page is consumed
+	// even if none of them were. Otherwise only the *PageError is returned.
+	PartialResults bool
+	// PageToken resumes a list from a token returned by the Token method of
+	// a previous list's iterator, for example after a crash part way through a
+	// long crawl. The token must come from a list of the same resource with
+	// the same PageSize, otherwise a KindInvalidArgs error is returned. It
+	// records the list's Offset and Limit, which needn't be set again; a Limit
+	// that ends the list somewhere else is also a KindInvalidArgs error.
+	PageToken string
+	// ReadAhead is the number of pages fetched in the background while the
+	// caller processes the current page. If zero, each page is only fetched
+	// when the iterator's Next method is called.
+	ReadAhead int
+	// Progress is called each time the iterator returns a page, with its
+	// position in the list, for example to show "page 3/65".
+	Progress func(iterator.Progress)
+}
+
+// paginatorOptions returns the options of a Paginator over resource.
+func (r *ListRequest) paginatorOptions(resource string) iterator.Options {
+	pageSize := r.PageSize
+	if pageSize == 0 {
+		pageSize = defaults.PageSize
+	}
+	return iterator.Options{
+		Limit:  pageSize,
+		Offset: r.Offset,
+		Max:    r.Limit,
+		Name:   resource,
+		Token:  r.PageToken,
+
+		ReadAhead: r.ReadAhead,
+		Progress:  r.Progress,
+	}
 }
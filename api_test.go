@@ -22,12 +22,15 @@ func TestGetRequest(t *testing.T) {
 	require.Empty(t, resource)
 	gErr := err.(*SDKError)
 	require.Equal(t, ErrMissingResources.Error(), gErr.Message)
-	require.Equal(t, CodeInvalidArgs, gErr.StatusCode)
+	require.Equal(t, KindInvalidArgs, gErr.Kind)
+	require.ErrorIs(t, err, ErrMissingResources)
+	require.ErrorIs(t, err, KindInvalidArgs)
 
 	r = GetRequest{ID: 1, Name: "foobar"}
 	resource, err = r.GetResource()
 	require.Empty(t, resource)
 	gErr = err.(*SDKError)
 	require.Equal(t, ErrMultipleResources.Error(), gErr.Message)
-	require.Equal(t, CodeInvalidArgs, gErr.StatusCode)
+	require.Equal(t, KindInvalidArgs, gErr.Kind)
+	require.ErrorIs(t, err, ErrMultipleResources)
 }
@@ -0,0 +1,46 @@
+package pokedex
+
+import (
+	"context"
+
+	"github.com/mdcurran/pokedex/iterator"
+)
+
+// BatchResult is the result of a single request of a batch. Exactly one of
+// Value and Err is set.
+type BatchResult[T any] struct {
+	Request GetRequest
+	Value   *T
+	Err     error
+}
+
+// BatchGet returns a resource for each request, fetched concurrently. At most
+// Options.Concurrency requests are made at once. The results are in the
+// same order as the requests, and a failed request doesn't affect the others.
+func (r *Resource[T]) BatchGet(ctx context.Context, reqs []GetRequest) []BatchResult[T] {
+	var (
+		results = make([]BatchResult[T], len(reqs))
+		pending = make([]*BatchResult[T], len(reqs))
+	)
+	for i, req := range reqs {
+		results[i].Request = req
+		pending[i] = &results[i]
+	}
+
+	// Each request fails individually, so an error is only returned if the
+	// context is cancelled. The requests that hadn't started fail without
+	// being made, even if they could be read from the cache.
+	err := iterator.ForEach(ctx, iterator.NewSlice(0, pending).All(ctx), r.client.concurrency, func(ctx context.Context, res *BatchResult[T]) error {
+		res.Value, res.Err = r.Get(ctx, res.Request)
+		return nil
+	})
+	if err != nil {
+		for i := range results {
+			if results[i].Value == nil && results[i].Err == nil {
+				results[i].Err = NewError(KindTransport, err, nil)
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,76 @@
+package pokedex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchGet(t *testing.T) {
+	ctx := context.Background()
+
+	var inFlight, maxInFlight atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		name := strings.TrimPrefix(r.URL.Path, "/nature/")
+		if name == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"name": %q}`, name)
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		Concurrency:      3,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	reqs := []GetRequest{{Name: "bold"}, {Name: "missing"}, {}}
+	for i := 0; i < 10; i++ {
+		reqs = append(reqs, GetRequest{ID: i + 1})
+	}
+
+	results := sdk.BatchGetNature(ctx, reqs)
+	require.Len(t, results, len(reqs))
+	require.LessOrEqual(t, maxInFlight.Load(), int64(3))
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "bold", results[0].Value.Name)
+	require.ErrorIs(t, results[1].Err, KindNotFound)
+	require.Nil(t, results[1].Value)
+	require.ErrorIs(t, results[2].Err, KindInvalidArgs)
+	for i, res := range results[3:] {
+		require.NoError(t, res.Err)
+		require.Equal(t, reqs[i+3], res.Request)
+		require.Equal(t, fmt.Sprint(i+1), res.Value.Name)
+	}
+
+	// Requests that haven't started when the context is cancelled fail.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	for _, res := range sdk.BatchGetNature(cancelled, reqs[3:]) {
+		require.ErrorIs(t, res.Err, context.Canceled)
+	}
+}
@@ -0,0 +1,67 @@
+package pokedex
+
+import (
+	"strings"
+
+	"github.com/mdcurran/pokedex/internal/store"
+)
+
+// CacheStats is a snapshot of the response cache's statistics. All values are
+// zero unless Options.CacheMetrics is enabled.
+type CacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	KeysAdded   uint64
+	KeysUpdated uint64
+	KeysEvicted uint64
+	// CostAdded and CostEvicted are the total size (in bytes) of the responses
+	// added to and evicted from the cache.
+	CostAdded   uint64
+	CostEvicted uint64
+}
+
+// CostUsed is the total size (in bytes) of the responses currently held in
+// the cache.
+func (s CacheStats) CostUsed() uint64 {
+	return s.CostAdded - s.CostEvicted
+}
+
+// HitRatio is the proportion of cache lookups that found a response.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// CacheStats returns a snapshot of the response cache's statistics. The
+// statistics count from when the client was created, or from the last call to
+// PurgeCache, which resets them all to zero.
+func (c *Client) CacheStats() CacheStats {
+	return CacheStats(c.cache.Stats())
+}
+
+// InvalidateCache removes the cached response for a single URL, for example
+// https://pokeapi.co/api/v2/pokemon/1.
+func (c *Client) InvalidateCache(url string) {
+	c.cache.Delete(url)
+}
+
+// InvalidateResource removes every cached response for an endpoint, for
+// example "pokemon". It returns the number of responses removed, which
+// doesn't include responses that had already been evicted or had expired.
+func (c *Client) InvalidateResource(resource string) int {
+	prefix := strings.TrimSuffix(c.baseURL.JoinPath(resource).String(), "/") + "/"
+	return c.cache.DeletePrefix(prefix)
+}
+
+// PurgeCache removes every response from the cache. It also resets every
+// statistic returned by CacheStats to zero, so purged responses don't count
+// towards CostUsed.
+func (c *Client) PurgeCache() {
+	c.cache.Clear()
+}
+
+// The SDK client only depends on the behaviour of store.Store, but uses the
+// concrete store.Cache for its statistics.
+var _ store.Store = (*store.Cache)(nil)
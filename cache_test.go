@@ -0,0 +1,67 @@
+package pokedex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheManagement(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"id": 1, "name": "hp"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		CacheMetrics:     true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	get := func(id int) {
+		_, err := sdk.GetStat(ctx, GetRequest{ID: id})
+		require.NoError(t, err)
+	}
+
+	get(1)
+	get(1)
+	get(2)
+	require.Equal(t, int64(2), requests.Load())
+
+	stats := sdk.CacheStats()
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(2), stats.Misses)
+	require.Equal(t, uint64(2), stats.KeysAdded)
+	// A cache hit doesn't write the response again.
+	require.Equal(t, uint64(0), stats.KeysUpdated)
+	require.InDelta(t, 1.0/3.0, stats.HitRatio(), 0.001)
+	require.Positive(t, stats.CostUsed())
+
+	sdk.InvalidateCache(srv.URL + "/stat/1")
+	get(1)
+	get(2)
+	require.Equal(t, int64(3), requests.Load())
+
+	require.Equal(t, 2, sdk.InvalidateResource("stat"))
+	get(1)
+	require.Equal(t, int64(4), requests.Load())
+
+	sdk.PurgeCache()
+	require.Equal(t, CacheStats{}, sdk.CacheStats())
+	get(1)
+	require.Equal(t, int64(5), requests.Load())
+}
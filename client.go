@@ -5,13 +5,19 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/mdcurran/pokedex/internal/defaults"
 	"github.com/mdcurran/pokedex/internal/store"
 	"github.com/mdcurran/pokedex/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var ErrClientClosed = errors.New("sdk client closed")
@@ -22,6 +28,12 @@ type Client struct {
 	http    *http.Client
 	baseURL *url.URL
 	cache   *store.Cache
+	logger  *slog.Logger
+	// telemetry holds the OpenTelemetry tracer and metric instruments.
+	telemetry *telemetry
+	// concurrency is the maximum number of requests made at once when
+	// fetching many resources.
+	concurrency int
 	// closed indicates if the SDK client has been previously closed.
 	// If closed is true the response cache has been shutdown. Therefore we
 	// want to prevent requests using a closed client, as no responses would
@@ -40,6 +52,37 @@ type Options struct {
 	// much larger value. However many "real-world" APIs will have much more
 	// frequent updates. 10 minutes seems like a reasonable compromise.
 	CacheTTL time.Duration
+	// CacheMetrics determines whether cache statistics are kept, so they can
+	// be read with Client.CacheStats().
+	CacheMetrics bool
+	// HTTPClient is used to make requests to the PokéAPI. If nil, a client
+	// with the configured Timeout is used. The client is copied, so applying
+	// Middleware doesn't modify it.
+	HTTPClient *http.Client
+	// Transport, if set, replaces the transport of the HTTP client. This is
+	// the innermost http.RoundTripper, which actually makes the request.
+	Transport http.RoundTripper
+	// OfflineDir, if set, serves every request from a local directory laid
+	// out like the PokeAPI/api-data repository, rather than the network. It's
+	// the directory holding api/v2, i.e. the data directory of an api-data
+	// checkout. Missing resources return a KindNotFound error.
+	OfflineDir string
+	// Middleware wraps the transport of the HTTP client. The first middleware
+	// is the first to see each request.
+	Middleware []Middleware
+	// Logger receives structured debug events for requests, cache lookups and
+	// list pages. If nil, nothing is logged.
+	Logger *slog.Logger
+	// TracerProvider creates spans around requests, cache lookups and list
+	// pages. If nil, no spans are recorded.
+	TracerProvider trace.TracerProvider
+	// MeterProvider records metrics for request latency, in-flight requests
+	// and cache hits and misses. If nil, no metrics are recorded.
+	MeterProvider metric.MeterProvider
+	// Concurrency is the maximum number of requests made at once when fetching
+	// many resources, by the BatchGet methods, Prefetch and snapshot exports.
+	// Defaults to 8.
+	Concurrency int
 }
 
 func defaultOptions() Options {
@@ -58,28 +101,69 @@ func New() (*Client, error) {
 }
 
 // NewWithOptions instantiates a PokéAPI SDK client with the provided client
-// settings.
+// settings. Invalid settings return a KindInvalidArgs error.
 func NewWithOptions(options Options) (*Client, error) {
 	u, err := url.Parse(options.BaseURL)
 	if err != nil {
-		return nil, err
+		return nil, NewError(KindInvalidArgs, err, nil)
 	}
 
 	cache, err := store.NewCache(store.CacheOptions{
 		MaximumSize: options.CacheMaximumSize,
 		TTL:         options.CacheTTL,
+		Metrics:     options.CacheMetrics,
 	})
 	if err != nil {
-		return nil, err
+		return nil, NewError(KindInvalidArgs, err, nil)
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.New(discardHandler{})
+	}
+
+	telemetry, err := newTelemetry(options.TracerProvider, options.MeterProvider)
+	if err != nil {
+		return nil, NewError(KindInternal, err, nil)
+	}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaults.Concurrency
 	}
 
 	return &Client{
-		http:    &http.Client{Timeout: options.Timeout},
-		baseURL: u,
-		cache:   cache,
+		http:      newHTTPClient(options, u),
+		baseURL:   u,
+		cache:     cache,
+		logger:    logger,
+		telemetry: telemetry,
+
+		concurrency: concurrency,
 	}, nil
 }
 
+// newHTTPClient builds the HTTP client used by the SDK client, wrapping its
+// transport with any middleware.
+func newHTTPClient(options Options, baseURL *url.URL) *http.Client {
+	hc := &http.Client{Timeout: options.Timeout}
+	if options.HTTPClient != nil {
+		copied := *options.HTTPClient
+		hc = &copied
+	}
+	if options.Transport != nil {
+		hc.Transport = options.Transport
+	}
+	if options.OfflineDir != "" {
+		hc.Transport = newOfflineTransport(options.OfflineDir, baseURL.Path)
+	}
+	if hc.Transport == nil {
+		hc.Transport = http.DefaultTransport
+	}
+	hc.Transport = chain(hc.Transport, options.Middleware)
+	return hc
+}
+
 // Close gracefully shutsdown the SDK client. The closed boolean is set to
 // true to prevent future calls to the PokeAPI being made using the current
 // client.
@@ -93,40 +177,115 @@ func (c *Client) Close() {
 // fetch will check the cache to see if the required data is already there. If
 // so, read from the cache and return without making an HTTP request.
 func (c *Client) fetch(ctx context.Context, url string) ([]byte, *http.Response, error) {
+	ctx, span := c.telemetry.tracer.Start(ctx, "pokedex.fetch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("url.full", url)),
+	)
+	defer span.End()
+
+	b, res, err := c.fetchURL(ctx, url)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return b, res, err
+}
+
+func (c *Client) fetchURL(ctx context.Context, url string) ([]byte, *http.Response, error) {
 	if c.closed {
-		return nil, nil, NewError(ErrClientClosed.Error(), CodeClientClosed, nil)
+		return nil, nil, newRequestError(KindClosed, ErrClientClosed, url, nil)
 	}
 
-	b, ok := c.cache.Get(url)
+	b, ok := c.cacheGet(ctx, url)
 	if ok {
 		return b, nil, nil
 	}
 
+	return c.request(ctx, url)
+}
+
+// cacheGet looks up a response body in the cache.
+func (c *Client) cacheGet(ctx context.Context, url string) ([]byte, bool) {
+	ctx, span := c.telemetry.tracer.Start(ctx, "pokedex.cache.get",
+		trace.WithAttributes(attribute.String("url.full", url)),
+	)
+	defer span.End()
+
+	b, ok := c.cache.Get(url)
+	span.SetAttributes(attribute.Bool("pokedex.cache.hit", ok))
+	if ok {
+		c.telemetry.cacheHits.Add(ctx, 1)
+		c.logger.DebugContext(ctx, "cache hit", slog.String("url", url))
+		return b, true
+	}
+	c.telemetry.cacheMisses.Add(ctx, 1)
+	c.logger.DebugContext(ctx, "cache miss", slog.String("url", url))
+	return nil, false
+}
+
+// request makes an HTTP GET request to url, returning the response body if
+// the PokéAPI responds successfully.
+func (c *Client) request(ctx context.Context, url string) ([]byte, *http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		return nil, nil, NewError(err.Error(), CodeInternal, nil)
+		return nil, nil, newRequestError(KindInternal, err, url, nil)
 	}
 
+	start := time.Now()
+	c.logger.DebugContext(ctx, "request started", slog.String("url", url))
+	c.telemetry.requestsInFlight.Add(ctx, 1)
+
 	res, err := c.do(req)
+	c.telemetry.requestsInFlight.Add(ctx, -1)
 	if err != nil {
-		return nil, nil, NewError(err.Error(), CodeInternal, nil)
-	}
-	if res != nil && res.StatusCode == http.StatusNotFound {
-		return nil, res, NewError("Not Found", res.StatusCode, res)
-	}
-	if res != nil && res.StatusCode != http.StatusOK {
-		return nil, res, NewError("Unexpected Error", res.StatusCode, res)
+		c.telemetry.requestDuration.Record(ctx, time.Since(start).Seconds())
+		c.logger.DebugContext(ctx, "request failed",
+			slog.String("url", url),
+			slog.Duration("duration", time.Since(start)),
+			slog.Any("error", err),
+		)
+		if errors.Is(err, ErrClientClosed) {
+			return nil, nil, newRequestError(KindClosed, err, url, nil)
+		}
+		return nil, nil, newRequestError(KindTransport, err, url, nil)
 	}
 	defer res.Body.Close()
 
-	b, err = io.ReadAll(res.Body)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	c.telemetry.requestDuration.Record(ctx, time.Since(start).Seconds(),
+		metric.WithAttributes(attribute.Int("http.response.status_code", res.StatusCode)),
+	)
+
+	if res.StatusCode != http.StatusOK {
+		c.logger.DebugContext(ctx, "request finished",
+			slog.String("url", url),
+			slog.Int("status", res.StatusCode),
+			slog.Duration("duration", time.Since(start)),
+		)
+		return nil, res, newStatusError(res)
+	}
+
+	b, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, res, NewError(err.Error(), http.StatusUnprocessableEntity, res)
+		return nil, res, newRequestError(KindTransport, err, url, res)
 	}
+	c.logger.DebugContext(ctx, "request finished",
+		slog.String("url", url),
+		slog.Int("status", res.StatusCode),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("bytes", len(b)),
+	)
 
 	return b, res, nil
 }
 
+// cacheSet adds a response body to the cache. It should only be called once
+// the body has been successfully decoded, so invalid responses aren't cached.
+func (c *Client) cacheSet(ctx context.Context, url string, body []byte) {
+	c.cache.Set(url, body)
+	c.logger.DebugContext(ctx, "cache set", slog.String("url", url), slog.Int("bytes", len(body)))
+}
+
 func (c *Client) fetchResourceList(ctx context.Context, resource string, start, end uint) (*models.NamedApiResourceList, error) {
 	u := c.baseURL.JoinPath(resource)
 	q := url.Values{}
@@ -142,7 +301,7 @@ func (c *Client) fetchResourceList(ctx context.Context, resource string, start,
 	var list *models.NamedApiResourceList
 	err = json.Unmarshal(b, &list)
 	if err != nil {
-		return nil, NewError(err.Error(), http.StatusUnprocessableEntity, res)
+		return nil, newRequestError(KindDecode, err, u.String(), res)
 	}
 
 	return list, nil
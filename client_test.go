@@ -27,5 +27,6 @@ func TestNewClient_BadURL(t *testing.T) {
 		BaseURL: "https://\nexample.com",
 	})
 	require.ErrorContains(t, err, "parse")
+	require.ErrorIs(t, err, KindInvalidArgs)
 	require.Nil(t, client)
 }
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+const header = "// Code generated by cmd/pokedex-gen. DO NOT EDIT.\n\n"
+
+// commentWidth is the width doc comments are wrapped to, excluding their
+// indentation and the leading "// ".
+const commentWidth = 77
+
+var funcs = template.FuncMap{
+	"comment": comment,
+}
+
+var modelTemplate = template.Must(template.New("model").Funcs(funcs).Parse(header + `package models
+{{ if .Imports }}
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+{{ end }}
+{{- range .Structs }}
+type {{ .Name }} struct {
+{{- range $i, $f := .Fields }}
+{{- if $i }}
+{{ end }}
+{{ comment "\t" (printf "%s corresponds to the JSON schema field %q." $f.Name $f.JSON) }}
+	{{ $f.Name }} {{ $f.Type }} ` + "`" + `json:"{{ $f.JSON }}{{ if not $f.Required }},omitempty{{ end }}"` + "`" + `
+{{- end }}
+}
+{{ end -}}
+`))
+
+var clientTemplate = template.Must(template.New("client").Funcs(funcs).Parse(header + `package pokedex
+
+import (
+	"context"
+	"iter"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+)
+
+type Get{{ .Type }}Response struct {
+	{{ .Type }} *models.{{ .Type }}
+}
+
+{{ comment "" (printf "Get%s returns a single %s according to an ID or name." .Type .Type) }}
+func (c *Client) Get{{ .Type }}(ctx context.Context, r GetRequest) (*Get{{ .Type }}Response, error) {
+	{{ .Var }}, err := NewResource[models.{{ .Type }}](c, "{{ .Endpoint }}").Get(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &Get{{ .Type }}Response{ {{- .Type }}: {{ .Var -}} }, nil
+}
+
+{{ comment "" (printf "BatchGet%s returns a %s for each request, fetched concurrently. The results are in the same order as the requests, each with its own error." .Type .Type) }}
+func (c *Client) BatchGet{{ .Type }}(ctx context.Context, rs []GetRequest) []BatchResult[models.{{ .Type }}] {
+	return NewResource[models.{{ .Type }}](c, "{{ .Endpoint }}").BatchGet(ctx, rs)
+}
+
+type List{{ .Plural }}Response struct {
+{{ comment "\t" (printf "Iterator iterates over the %s. It can be replaced, for example by an iterator.Slice in tests." .Plural) }}
+	Iterator iterator.Iterator[*models.{{ .Type }}]
+}
+
+// Paginator returns the Iterator as a Paginator, for the methods specific to
+// pagination such as Token and Seek. It returns nil if the Iterator has been
+// replaced by another implementation.
+func (r *List{{ .Plural }}Response) Paginator() *iterator.Paginator[*models.{{ .Type }}] {
+	p, _ := r.Iterator.(*iterator.Paginator[*models.{{ .Type }}])
+	return p
+}
+
+{{ comment "" (printf "All returns an iterator over each remaining %s of the Iterator, for use with range. See iterator.All." .Type) }}
+func (r *List{{ .Plural }}Response) All(ctx context.Context) iter.Seq2[*models.{{ .Type }}, error] {
+	return iterator.All(ctx, r.Iterator)
+}
+
+// Pages returns an iterator over the remaining pages of the Iterator, for use
+// with range. See iterator.Pages.
+func (r *List{{ .Plural }}Response) Pages(ctx context.Context) iter.Seq2[[]*models.{{ .Type }}, error] {
+	return iterator.Pages(ctx, r.Iterator)
+}
+
+{{ comment "" (printf "List%s returns an iterator with a user-provided page size over all %s." .Plural .Plural) }}
+func (c *Client) List{{ .Plural }}(ctx context.Context, r ListRequest) (*List{{ .Plural }}Response, error) {
+	it, err := NewResource[models.{{ .Type }}](c, "{{ .Endpoint }}").List(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &List{{ .Plural }}Response{Iterator: it}, nil
+}
+
+{{ comment "" (printf "List%sRefs returns an iterator with a user-provided page size over the names of all %s, without fetching each %s. A ref can be fetched later with Hydrate%s." .Type .Plural .Type .Type) }}
+func (c *Client) List{{ .Type }}Refs(ctx context.Context, r ListRequest) (*ListRefsResponse, error) {
+	it, err := NewResource[models.{{ .Type }}](c, "{{ .Endpoint }}").ListRefs(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &ListRefsResponse{Iterator: it}, nil
+}
+
+{{ comment "" (printf "Hydrate%s returns the %s a ref returned by List%sRefs points to." .Type .Type .Type) }}
+func (c *Client) Hydrate{{ .Type }}(ctx context.Context, ref models.NamedApiResource) (*Get{{ .Type }}Response, error) {
+	{{ .Var }}, err := NewResource[models.{{ .Type }}](c, "{{ .Endpoint }}").Hydrate(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &Get{{ .Type }}Response{ {{- .Type }}: {{ .Var -}} }, nil
+}
+`))
+
+var registryTemplate = template.Must(template.New("registry").Parse(header + `package models
+
+// schemaModels maps each schema in api/ to the model generated from it, for
+// the schema conformance tests.
+var schemaModels = map[string]func() any{
+{{- range . }}
+	"{{ .Name }}.json": func() any { return new({{ .Type }}) },
+{{- end }}
+}
+`))
+
+// generateModel returns the Go source of the types generated from f.
+func generateModel(f *file) ([]byte, error) {
+	return execute(modelTemplate, f, f.Name)
+}
+
+// generateClient returns the Go source of the Get and List methods of the
+// resource f.
+func generateClient(f *file) ([]byte, error) {
+	return execute(clientTemplate, f, f.Name)
+}
+
+// generateRegistry returns the Go source of the test registry mapping each
+// schema in files to its model.
+func generateRegistry(files []*file) ([]byte, error) {
+	return execute(registryTemplate, files, "registry")
+}
+
+func execute(t *template.Template, data any, name string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: format generated code: %w\n%s", name, err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// comment wraps text into a doc comment, each line prefixed with indent.
+func comment(indent, text string) string {
+	var (
+		lines []string
+		line  string
+	)
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > commentWidth {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	lines = append(lines, line)
+
+	for i, l := range lines {
+		lines[i] = indent + "// " + l
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,66 @@
+// Command pokedex-gen generates the SDK from the PokéAPI JSON schemas in api/.
+// For each schema it writes the Go types to models/, and for each schema of an
+// endpoint (one with an "id" and a "name") it writes the client's Get and List
+// methods to the root package. It also writes the registry of models used by
+// the schema conformance tests in models/. Adding a resource to the SDK is a
+// matter of adding its schema and running:
+//
+//	go run ./cmd/pokedex-gen
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var (
+		schemas = flag.String("schemas", "api", "directory of the JSON schemas")
+		models  = flag.String("models", "models", "directory to write the models to")
+		client  = flag.String("client", ".", "directory to write the client methods to")
+	)
+	flag.Parse()
+
+	err := run(*schemas, *models, *client)
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(schemas, models, client string) error {
+	files, err := parseDir(schemas)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		src, err := generateModel(f)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(filepath.Join(models, f.Name+".go"), src, 0o644)
+		if err != nil {
+			return err
+		}
+
+		if !f.Resource {
+			continue
+		}
+		src, err = generateClient(f)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(filepath.Join(client, f.Name+".go"), src, 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	src, err := generateRegistry(files)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(models, "schemas_gen_test.go"), src, 0o644)
+}
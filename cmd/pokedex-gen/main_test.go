@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerated fails if the generated code in the repository is out of date,
+// either because a schema changed or because it was edited by hand.
+func TestGenerated(t *testing.T) {
+	var (
+		root   = filepath.Join("..", "..")
+		models = t.TempDir()
+		client = t.TempDir()
+	)
+	require.NoError(t, run(filepath.Join(root, "api"), models, client))
+
+	for dir, committed := range map[string]string{models: filepath.Join(root, "models"), client: root} {
+		generated, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.NotEmpty(t, generated)
+
+		for _, entry := range generated {
+			want, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			require.NoError(t, err)
+			got, err := os.ReadFile(filepath.Join(committed, entry.Name()))
+			require.NoError(t, err)
+			require.Equal(t, string(want), string(got), "%s is out of date, run `make generate`", entry.Name())
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	files, err := parseDir(filepath.Join("..", "..", "api"))
+	require.NoError(t, err)
+
+	resources := make(map[string]*file)
+	for _, f := range files {
+		if f.Resource {
+			resources[f.Type] = f
+		}
+	}
+	require.Len(t, resources, 3)
+	require.Equal(t, "Natures", resources["Nature"].Plural())
+	require.Equal(t, "Pokemon", resources["Pokemon"].Plural())
+
+	// NamedApiResource is referenced by Stat, rather than generated again.
+	fields := make(map[string]string)
+	for _, st := range resources["Stat"].Structs {
+		require.NotEqual(t, "NamedApiResource", st.Name)
+		if st.Name == "Stat" {
+			for _, f := range st.Fields {
+				fields[f.Name] = f.Type
+			}
+		}
+	}
+	require.Equal(t, "*NamedApiResource", fields["MoveDamageClass"])
+	require.Equal(t, "[]ApiResource", fields["Characteristics"])
+	require.Equal(t, "int", fields["ID"])
+}
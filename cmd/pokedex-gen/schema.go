@@ -0,0 +1,315 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// schema is the subset of JSON schema used by the PokéAPI's schemas.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       schemaType         `json:"type"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *schema            `json:"items"`
+	AnyOf      []*schema          `json:"anyOf"`
+	OneOf      []*schema          `json:"oneOf"`
+}
+
+// schemaType is the "type" keyword of a schema, which is either a single type
+// or a list of types.
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = schemaType{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*t = multiple
+	return nil
+}
+
+// file is a schema in the schema directory, and the Go types generated from
+// it.
+type file struct {
+	// Name is the schema's file name without its extension, for example
+	// "named_api_resource".
+	Name string
+	// Type is the Go type of the schema's root object, for example
+	// "NamedApiResource".
+	Type    string
+	Structs []*goStruct
+	// Resource is true if the schema describes a PokéAPI endpoint, rather
+	// than a type shared between them. Resources have an "id" and a "name".
+	Resource bool
+	// Imports are the packages the generated types depend on.
+	Imports []string
+}
+
+type goStruct struct {
+	Name   string
+	Fields []goField
+}
+
+type goField struct {
+	Name string
+	JSON string
+	Type string
+	// Required fields are always present, so aren't omitted when empty.
+	Required bool
+}
+
+// Endpoint returns the PokéAPI endpoint of a resource, for example
+// "pokemon-species".
+func (f *file) Endpoint() string {
+	return strings.ReplaceAll(f.Name, "_", "-")
+}
+
+// Plural returns the plural of the resource's Go type, used to name its List
+// method.
+func (f *file) Plural() string {
+	if p, ok := irregularPlurals[f.Type]; ok {
+		return p
+	}
+	switch {
+	case strings.HasSuffix(f.Type, "y") && !strings.ContainsAny(f.Type[len(f.Type)-2:len(f.Type)-1], "aeiou"):
+		return strings.TrimSuffix(f.Type, "y") + "ies"
+	case strings.HasSuffix(f.Type, "s"):
+		return f.Type + "es"
+	}
+	return f.Type + "s"
+}
+
+// Var returns the name of a variable holding the resource.
+func (f *file) Var() string {
+	return strings.ToLower(f.Type[:1]) + f.Type[1:]
+}
+
+// irregularPlurals are resources whose plural isn't formed by adding an "s".
+var irregularPlurals = map[string]string{
+	"Pokemon":        "Pokemon",
+	"PokemonSpecies": "PokemonSpecies",
+}
+
+// parser converts schemas to Go types. A "$ref" to another schema uses that
+// schema's root type, so types shared between schemas are generated once.
+type parser struct {
+	dir   string
+	files map[string]*file
+}
+
+func parseDir(dir string) ([]*file, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no schemas found in %s", dir)
+	}
+
+	p := &parser{dir: dir, files: make(map[string]*file)}
+	var files []*file
+	for _, path := range paths {
+		f, err := p.parseFile(filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func (p *parser) parseFile(name string) (*file, error) {
+	b, err := os.ReadFile(filepath.Join(p.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	var s schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	f := &file{
+		Name: strings.TrimSuffix(name, filepath.Ext(name)),
+		Type: typeName(name),
+	}
+	_, hasID := s.Properties["id"]
+	_, hasName := s.Properties["name"]
+	f.Resource = hasID && hasName
+
+	if _, err := p.goType(f, f.Type, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	sort.Slice(f.Structs, func(i, j int) bool { return f.Structs[i].Name < f.Structs[j].Name })
+	sort.Strings(f.Imports)
+
+	return f, nil
+}
+
+// goType returns the Go type of s, adding a struct to f for each object. name
+// is the name of the struct if s is an object.
+func (p *parser) goType(f *file, name string, s *schema) (string, error) {
+	if s.Ref != "" {
+		if strings.Contains(s.Ref, "#") {
+			return "", fmt.Errorf("unsupported $ref %q: only references to other schema files are supported", s.Ref)
+		}
+		return typeName(s.Ref), nil
+	}
+
+	// A union of null and a single other schema is a nullable field.
+	if union := append(s.AnyOf, s.OneOf...); len(union) > 0 {
+		var (
+			nullable bool
+			options  []*schema
+		)
+		for _, option := range union {
+			if len(option.Type) == 1 && option.Type[0] == "null" {
+				nullable = true
+				continue
+			}
+			options = append(options, option)
+		}
+		if len(options) != 1 {
+			// Unions of several types can't be represented by a single Go
+			// type, so are left for the caller to decode.
+			f.addImport("encoding/json")
+			return "json.RawMessage", nil
+		}
+		t, err := p.goType(f, name, options[0])
+		if err != nil {
+			return "", err
+		}
+		return pointer(t, nullable), nil
+	}
+
+	var (
+		nullable bool
+		types    []string
+	)
+	for _, t := range s.Type {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		types = append(types, t)
+	}
+	if len(types) != 1 {
+		f.addImport("encoding/json")
+		return "json.RawMessage", nil
+	}
+
+	var t string
+	switch types[0] {
+	case "string":
+		t = "string"
+	case "integer":
+		t = "int"
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]any", nil
+		}
+		elem, err := p.goType(f, name+"Elem", s.Items)
+		if err != nil {
+			return "", err
+		}
+		t = "[]" + elem
+	case "object":
+		if len(s.Properties) == 0 {
+			return pointer("map[string]any", nullable), nil
+		}
+		if err := p.addStruct(f, name, s); err != nil {
+			return "", err
+		}
+		t = name
+	default:
+		return "", fmt.Errorf("unsupported type %q", types[0])
+	}
+	return pointer(t, nullable), nil
+}
+
+func (p *parser) addStruct(f *file, name string, s *schema) error {
+	required := make(map[string]bool)
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	st := &goStruct{Name: name}
+	f.Structs = append(f.Structs, st)
+
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		field := goField{
+			Name:     fieldName(prop),
+			JSON:     prop,
+			Required: required[prop],
+		}
+		t, err := p.goType(f, name+field.Name, s.Properties[prop])
+		if err != nil {
+			return fmt.Errorf("%s: %w", prop, err)
+		}
+		field.Type = pointer(t, !field.Required)
+		st.Fields = append(st.Fields, field)
+	}
+	return nil
+}
+
+func (f *file) addImport(path string) {
+	for _, imp := range f.Imports {
+		if imp == path {
+			return
+		}
+	}
+	f.Imports = append(f.Imports, path)
+}
+
+// pointer makes t a pointer type if it can be null or absent. Slices, maps
+// and raw JSON are already nil when empty so are left as they are.
+func pointer(t string, nullable bool) string {
+	if !nullable || strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "json.RawMessage" {
+		return t
+	}
+	return "*" + t
+}
+
+// typeName converts a schema file name, such as "named_api_resource.json",
+// into the name of its Go type, such as "NamedApiResource".
+func typeName(file string) string {
+	return fieldName(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+}
+
+// initialisms are capitalised entirely in Go names.
+var initialisms = map[string]string{
+	"id": "ID",
+}
+
+// fieldName converts a JSON property, such as "official-artwork", into an
+// exported Go name, such as "OfficialArtwork".
+func fieldName(prop string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(prop, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		if initialism, ok := initialisms[word]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+// Command pokedex-snapshot exports PokéAPI resources to a directory laid out
+// like the PokeAPI/api-data repository, for example:
+//
+//	go run ./cmd/pokedex-snapshot -out testdata -resources nature,stat
+//
+// The snapshot can be served by the SDK client with Options.OfflineDir.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mdcurran/pokedex"
+	"github.com/mdcurran/pokedex/internal/defaults"
+	"github.com/mdcurran/pokedex/snapshot"
+)
+
+func main() {
+	var (
+		out         = flag.String("out", "snapshot", "directory to write the snapshot to")
+		resources   = flag.String("resources", "pokemon,nature,stat", "comma-separated list of endpoints to export")
+		baseURL     = flag.String("base-url", "https://pokeapi.co/api/v2", "base URL of the PokéAPI")
+		concurrency = flag.Int("concurrency", defaults.Concurrency, "maximum number of resources fetched at once")
+		timeout     = flag.Duration("timeout", 30*time.Second, "timeout of each request")
+	)
+	flag.Parse()
+
+	err := run(*out, split(*resources), *baseURL, *concurrency, *timeout)
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// split returns the endpoints in a comma-separated list, ignoring whitespace
+// around each one and empty entries, so "nature, stat," is "nature" and
+// "stat".
+func split(list string) []string {
+	var resources []string
+	for _, r := range strings.Split(list, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+func run(out string, resources []string, baseURL string, concurrency int, timeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	sdk, err := pokedex.NewWithOptions(pokedex.Options{
+		BaseURL:          baseURL,
+		Timeout:          timeout,
+		CacheMaximumSize: 1 << 27,
+		CacheTTL:         10 * time.Minute,
+		Concurrency:      concurrency,
+	})
+	if err != nil {
+		return err
+	}
+	defer sdk.Close()
+
+	return snapshot.Export(ctx, sdk, out, resources...)
+}
@@ -2,36 +2,204 @@ package pokedex
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
+// ErrorKind categorises an SDKError, so callers can handle a class of errors
+// without inspecting HTTP status codes. An ErrorKind can be used as the target
+// of errors.Is, for example:
+//
+//	if errors.Is(err, pokedex.KindNotFound) {
+//		// ...
+//	}
+type ErrorKind int
+
 const (
-	// CodeInternal is an error code that should be returned when an error
-	// occurs that's not in the HTTP request lifecycle.
-	CodeInternal int = 600
-	// CodeClientClosed is returned when client.Close() has been previously
-	// called. A client that is closed is unable to cache API responses, so
-	// we want to force users to create a new client.
-	CodeClientClosed int = 601
-	// CodeInvalidArgs indicate an API request using the SDK has malformed
+	// KindInternal is returned when an error occurs that's not in the HTTP
+	// request lifecycle.
+	KindInternal ErrorKind = iota
+	// KindNotFound is returned when the PokéAPI responds with a 404.
+	KindNotFound
+	// KindRateLimited is returned when the PokéAPI responds with a 429.
+	KindRateLimited
+	// KindUnexpectedStatus is returned when the PokéAPI responds with any
+	// other non-200 status.
+	KindUnexpectedStatus
+	// KindDecode is returned when a response body can't be decoded into the
+	// expected model.
+	KindDecode
+	// KindTransport is returned when the HTTP request itself fails, for
+	// example due to a timeout or a dropped connection.
+	KindTransport
+	// KindInvalidArgs indicates an API request using the SDK has malformed
 	// arguments.
-	CodeInvalidArgs int = 602
+	KindInvalidArgs
+	// KindClosed is returned when client.Close() has been previously called.
+	// A client that is closed is unable to cache API responses, so we want to
+	// force users to create a new client.
+	KindClosed
 )
 
+var kindNames = map[ErrorKind]string{
+	KindInternal:         "internal",
+	KindNotFound:         "not_found",
+	KindRateLimited:      "rate_limited",
+	KindUnexpectedStatus: "unexpected_status",
+	KindDecode:           "decode",
+	KindTransport:        "transport",
+	KindInvalidArgs:      "invalid_args",
+	KindClosed:           "closed",
+}
+
+func (k ErrorKind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("kind(%d)", int(k))
+}
+
+func (k ErrorKind) Error() string {
+	return k.String()
+}
+
+// bodySnippetSize is the maximum number of bytes of an unsuccessful response
+// body kept on an SDKError for debugging.
+const bodySnippetSize = 512
+
 type SDKError struct {
-	Message    string
+	Kind    ErrorKind
+	Message string
+	// StatusCode is the HTTP status of the response. It is 0 if the error
+	// occurred before a response was received.
 	StatusCode int
-	Response   *http.Response
+	// URL is the URL of the request that failed, if any.
+	URL string
+	// Body holds the start of an unsuccessful response body, truncated to
+	// 512 bytes.
+	Body     string
+	Response *http.Response
+	// Err is the underlying cause of the error, if any.
+	Err error
 }
 
-func NewError(message string, code int, response *http.Response) *SDKError {
-	return &SDKError{
-		Message:    message,
-		StatusCode: code,
-		Response:   response,
+// NewError creates an SDKError of the given kind caused by err. If response
+// is non-nil its status code and request URL are recorded on the error.
+func NewError(kind ErrorKind, err error, response *http.Response) *SDKError {
+	e := &SDKError{
+		Kind:     kind,
+		Response: response,
+		Err:      err,
 	}
+	if err != nil {
+		e.Message = err.Error()
+	}
+	if response != nil {
+		e.StatusCode = response.StatusCode
+		if response.Request != nil {
+			e.URL = response.Request.URL.String()
+		}
+	}
+	return e
+}
+
+// newRequestError creates an SDKError for a failed request to url. response is
+// nil if the error occurred before a response was received, or if the
+// response body was read from the cache.
+func newRequestError(kind ErrorKind, err error, url string, response *http.Response) *SDKError {
+	e := NewError(kind, err, response)
+	e.URL = url
+	return e
+}
+
+// newStatusError creates an SDKError from an unsuccessful HTTP response,
+// keeping a snippet of the response body. The caller is responsible for
+// closing the response body.
+func newStatusError(response *http.Response) *SDKError {
+	e := NewError(KindUnexpectedStatus, nil, response)
+	e.Message = "Unexpected Error"
+	switch response.StatusCode {
+	case http.StatusNotFound:
+		e.Kind = KindNotFound
+		e.Message = "Not Found"
+	case http.StatusTooManyRequests:
+		e.Kind = KindRateLimited
+		e.Message = "Too Many Requests"
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(response.Body, bodySnippetSize))
+	e.Body = string(snippet)
+
+	return e
 }
 
 func (e *SDKError) Error() string {
-	return fmt.Sprintf("message: %q status: %d", e.Message, e.StatusCode)
+	var b strings.Builder
+	fmt.Fprintf(&b, "kind: %s message: %q", e.Kind, e.Message)
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " status: %d", e.StatusCode)
+	}
+	if e.URL != "" {
+		fmt.Fprintf(&b, " url: %s", e.URL)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying cause of the error, so sentinel errors such as
+// ErrClientClosed can be matched with errors.Is.
+func (e *SDKError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error is of the given ErrorKind.
+func (e *SDKError) Is(target error) bool {
+	kind, ok := target.(ErrorKind)
+	return ok && e.Kind == kind
+}
+
+// ResourceError reports that a single named resource in a page could not be
+// fetched.
+type ResourceError struct {
+	Name string
+	Err  *SDKError
+}
+
+// PageError is returned when one or more resources in a page of a paginated
+// list could not be fetched. Each failed resource is reported individually,
+// in the order it appeared in the page.
+type PageError struct {
+	// Resource is the name of the endpoint being listed, for example
+	// "pokemon".
+	Resource string
+	Errors   []ResourceError
+	// partial is whether the resources that were fetched are returned
+	// alongside the error, as requested by ListRequest.PartialResults.
+	partial bool
+}
+
+func (e *PageError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to fetch %d %s resource(s):", len(e.Errors), e.Resource)
+	for _, re := range e.Errors {
+		fmt.Fprintf(&b, " %s (%s);", re.Name, re.Err)
+	}
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+// Partial reports whether the resources in the page that were fetched are
+// returned alongside the error. If so, the page is consumed even if none of
+// them were, so iterating over the list continues with the next page.
+func (e *PageError) Partial() bool {
+	return e.partial
+}
+
+// Unwrap returns the errors of each failed resource, so errors.Is and
+// errors.As can be used to inspect them.
+func (e *PageError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, re := range e.Errors {
+		errs[i] = re.Err
+	}
+	return errs
 }
@@ -0,0 +1,68 @@
+package pokedex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKError_Is(t *testing.T) {
+	cause := errors.New("something broke")
+	err := fmt.Errorf("wrapped: %w", NewError(KindTransport, cause, nil))
+
+	require.ErrorIs(t, err, KindTransport)
+	require.ErrorIs(t, err, cause)
+	require.NotErrorIs(t, err, KindNotFound)
+
+	var sdkErr *SDKError
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, "something broke", sdkErr.Message)
+	require.Equal(t, `kind: transport message: "something broke"`, sdkErr.Error())
+}
+
+func TestSDKError_Kinds(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stat/1":
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, "slow down")
+		case "/stat/2":
+			fmt.Fprint(w, "not json")
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	_, err = sdk.GetStat(ctx, GetRequest{ID: 1})
+	require.ErrorIs(t, err, KindRateLimited)
+	var sdkErr *SDKError
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, http.StatusTooManyRequests, sdkErr.StatusCode)
+	require.Equal(t, "slow down", sdkErr.Body)
+
+	_, err = sdk.GetStat(ctx, GetRequest{ID: 2})
+	require.ErrorIs(t, err, KindDecode)
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, srv.URL+"/stat/2", sdkErr.URL)
+
+	// Nothing is listening on this server once it's closed.
+	srv.Close()
+	_, err = sdk.GetStat(ctx, GetRequest{ID: 3})
+	require.ErrorIs(t, err, KindTransport)
+}
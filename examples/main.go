@@ -6,7 +6,6 @@ import (
 	"log"
 
 	"github.com/mdcurran/pokedex"
-	"github.com/mdcurran/pokedex/iterator"
 )
 
 // These are the examples from the README.
@@ -53,19 +52,12 @@ func list() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	it := res.Iterator
 
-	for {
-		natures, err := it.Next(ctx)
-		if err == iterator.EndOfIterator {
-			break
-		}
+	for n, err := range res.All(ctx) {
 		if err != nil {
 			log.Fatal(err)
 		}
-		for _, n := range natures {
-			fmt.Printf("id: %d name: %s\n", n.ID, n.Name)
-		}
+		fmt.Printf("id: %d name: %s\n", n.ID, n.Name)
 	}
 
 	// ...
@@ -0,0 +1,210 @@
+package faker
+
+// ref is a named resource with its ID, used to build NamedApiResources with
+// URLs that match their ID.
+type ref struct {
+	id   int
+	name string
+}
+
+// The PokéAPI's stats, in ID order. Only the first six are used in battle by
+// every Pokémon; accuracy and evasion are battle-only stats.
+var stats = []ref{
+	{1, "hp"},
+	{2, "attack"},
+	{3, "defense"},
+	{4, "special-attack"},
+	{5, "special-defense"},
+	{6, "speed"},
+	{7, "accuracy"},
+	{8, "evasion"},
+}
+
+// statDamageClass is the move damage class of each stat that has one.
+var statDamageClass = map[int]ref{
+	2: {2, "physical"},
+	3: {2, "physical"},
+	4: {3, "special"},
+	5: {3, "special"},
+}
+
+// statFlavor is the berry flavor associated with each stat natures can
+// affect. A nature likes the flavor of the stat it increases and hates the
+// flavor of the stat it decreases.
+var statFlavor = map[int]ref{
+	2: {1, "spicy"},
+	3: {5, "sour"},
+	4: {2, "dry"},
+	5: {4, "bitter"},
+	6: {3, "sweet"},
+}
+
+type nature struct {
+	ref
+	// increased and decreased are stat IDs. Neutral natures increase and
+	// decrease the same stat, so the PokéAPI reports neither.
+	increased int
+	decreased int
+}
+
+// The PokéAPI's natures, in ID order.
+var natures = []nature{
+	{ref{1, "hardy"}, 2, 2},
+	{ref{2, "bold"}, 3, 2},
+	{ref{3, "modest"}, 4, 2},
+	{ref{4, "calm"}, 5, 2},
+	{ref{5, "timid"}, 6, 2},
+	{ref{6, "lonely"}, 2, 3},
+	{ref{7, "docile"}, 3, 3},
+	{ref{8, "mild"}, 4, 3},
+	{ref{9, "gentle"}, 5, 3},
+	{ref{10, "hasty"}, 6, 3},
+	{ref{11, "adamant"}, 2, 4},
+	{ref{12, "impish"}, 3, 4},
+	{ref{13, "bashful"}, 4, 4},
+	{ref{14, "careful"}, 5, 4},
+	{ref{15, "rash"}, 4, 5},
+	{ref{16, "jolly"}, 6, 4},
+	{ref{17, "naughty"}, 2, 5},
+	{ref{18, "lax"}, 3, 5},
+	{ref{19, "quirky"}, 5, 5},
+	{ref{20, "naive"}, 6, 5},
+	{ref{21, "brave"}, 2, 6},
+	{ref{22, "relaxed"}, 3, 6},
+	{ref{23, "quiet"}, 4, 6},
+	{ref{24, "sassy"}, 5, 6},
+	{ref{25, "serious"}, 6, 6},
+}
+
+var pokeathlonStats = []ref{
+	{1, "speed"},
+	{2, "power"},
+	{3, "skill"},
+	{4, "stamina"},
+	{5, "jump"},
+}
+
+var moveBattleStyles = []ref{
+	{1, "attack"},
+	{2, "defense"},
+	{3, "support"},
+}
+
+var languages = []ref{
+	{1, "ja-Hrkt"},
+	{5, "fr"},
+	{6, "de"},
+	{7, "es"},
+	{9, "en"},
+}
+
+var types = []ref{
+	{1, "normal"},
+	{2, "fighting"},
+	{3, "flying"},
+	{4, "poison"},
+	{5, "ground"},
+	{6, "rock"},
+	{7, "bug"},
+	{8, "ghost"},
+	{9, "steel"},
+	{10, "fire"},
+	{11, "water"},
+	{12, "grass"},
+	{13, "electric"},
+	{14, "psychic"},
+	{15, "ice"},
+	{16, "dragon"},
+	{17, "dark"},
+	{18, "fairy"},
+}
+
+var abilities = []ref{
+	{1, "stench"},
+	{2, "drizzle"},
+	{3, "speed-boost"},
+	{4, "battle-armor"},
+	{5, "sturdy"},
+	{6, "damp"},
+	{7, "limber"},
+	{8, "sand-veil"},
+	{9, "static"},
+	{10, "volt-absorb"},
+	{11, "water-absorb"},
+	{12, "oblivious"},
+	{13, "cloud-nine"},
+	{14, "compound-eyes"},
+	{15, "insomnia"},
+	{16, "color-change"},
+	{17, "immunity"},
+	{18, "flash-fire"},
+	{19, "shield-dust"},
+	{20, "own-tempo"},
+}
+
+var moves = []ref{
+	{1, "pound"},
+	{2, "karate-chop"},
+	{3, "double-slap"},
+	{4, "comet-punch"},
+	{5, "mega-punch"},
+	{6, "pay-day"},
+	{7, "fire-punch"},
+	{8, "ice-punch"},
+	{9, "thunder-punch"},
+	{10, "scratch"},
+	{14, "swords-dance"},
+	{16, "gust"},
+	{33, "tackle"},
+	{39, "tail-whip"},
+	{43, "leer"},
+	{45, "growl"},
+	{74, "growth"},
+	{97, "agility"},
+	{104, "double-team"},
+	{106, "harden"},
+}
+
+var moveLearnMethods = []ref{
+	{1, "level-up"},
+	{2, "egg"},
+	{3, "tutor"},
+	{4, "machine"},
+}
+
+var versionGroups = []ref{
+	{1, "red-blue"},
+	{2, "yellow"},
+	{3, "gold-silver"},
+	{4, "crystal"},
+	{5, "ruby-sapphire"},
+	{6, "emerald"},
+}
+
+var versions = []ref{
+	{1, "red"},
+	{2, "blue"},
+	{3, "yellow"},
+	{4, "gold"},
+	{5, "silver"},
+	{6, "crystal"},
+	{7, "ruby"},
+	{8, "sapphire"},
+	{9, "emerald"},
+}
+
+var items = []ref{
+	{126, "cheri-berry"},
+	{132, "oran-berry"},
+	{134, "lum-berry"},
+	{135, "sitrus-berry"},
+	{213, "lucky-egg"},
+}
+
+// syllables are combined to generate pronounceable Pokémon names.
+var syllables = []string{
+	"bul", "ba", "saur", "char", "man", "der", "squir", "tle", "cat", "er",
+	"pie", "pid", "geot", "rat", "ta", "spear", "ow", "ek", "ans", "pi",
+	"ka", "chu", "san", "drew", "ni", "do", "ran", "vul", "pix", "zu",
+	"bat", "gol", "odd", "ish", "gloom", "par", "as", "ven", "mo", "nat",
+}
@@ -0,0 +1,354 @@
+// Package faker generates realistic fake PokéAPI resources for tests.
+//
+// Every field of the generated models is populated, using the PokéAPI's real
+// stats, natures, types, etc. so that resources reference each other
+// consistently. For example a Nature's increased stat is one of the Stats
+// returned by Faker.Stats, and that Stat lists the Nature in its affecting
+// natures. Each resource URL matches the Faker's base URL and the resource's
+// ID.
+//
+// A Faker always generates the same data for the same seed.
+package faker
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/mdcurran/pokedex/models"
+)
+
+const (
+	defaultBaseURL = "https://pokeapi.co/api/v2"
+	spritesURL     = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon"
+	// maximumPokemonID is the ID of the last Pokémon in the National Pokédex
+	// when the PokéAPI was last checked.
+	maximumPokemonID = 1025
+)
+
+type Options struct {
+	// Seed seeds the generated data. If zero, different data is generated
+	// each time.
+	Seed int64
+	// BaseURL is the PokéAPI base URL used in every resource URL. Defaults to
+	// https://pokeapi.co/api/v2.
+	BaseURL string
+}
+
+type Faker struct {
+	instance *gofakeit.Faker
+	baseURL  string
+	// names holds every Pokémon name generated so far, so each is unique.
+	names map[string]struct{}
+}
+
+// NewFaker creates a Faker that generates different data each time.
+func NewFaker() *Faker {
+	return NewFakerWithOptions(Options{})
+}
+
+// NewFakerWithOptions creates a Faker with the provided settings.
+func NewFakerWithOptions(options Options) *Faker {
+	if options.BaseURL == "" {
+		options.BaseURL = defaultBaseURL
+	}
+	return &Faker{
+		instance: gofakeit.New(options.Seed),
+		baseURL:  strings.TrimSuffix(options.BaseURL, "/"),
+		names:    make(map[string]struct{}),
+	}
+}
+
+// GenerateNature returns one of the PokéAPI's natures, chosen at random.
+func (f *Faker) GenerateNature() *models.Nature {
+	return f.nature(natures[f.instance.IntRange(0, len(natures)-1)])
+}
+
+// Natures returns every nature, in ID order.
+func (f *Faker) Natures() []*models.Nature {
+	ns := make([]*models.Nature, len(natures))
+	for i, n := range natures {
+		ns[i] = f.nature(n)
+	}
+	return ns
+}
+
+func (f *Faker) nature(n nature) *models.Nature {
+	nat := &models.Nature{
+		ID:    n.id,
+		Name:  n.name,
+		Names: []models.NatureNamesElem{},
+	}
+	if n.increased != n.decreased {
+		nat.IncreasedStat = f.resource("stat", stats[n.increased-1])
+		nat.DecreasedStat = f.resource("stat", stats[n.decreased-1])
+		nat.LikesFlavor = f.resource("berry-flavor", statFlavor[n.increased])
+		nat.HatesFlavor = f.resource("berry-flavor", statFlavor[n.decreased])
+	}
+
+	for _, l := range languages {
+		nat.Names = append(nat.Names, models.NatureNamesElem{
+			Language: *f.resource("language", l),
+			Name:     title(n.name),
+		})
+	}
+	for _, s := range pokeathlonStats {
+		nat.PokeathlonStatChanges = append(nat.PokeathlonStatChanges, models.NaturePokeathlonStatChangesElem{
+			MaxChange:      f.instance.IntRange(-2, 2),
+			PokeathlonStat: *f.resource("pokeathlon-stat", s),
+		})
+	}
+	for _, s := range moveBattleStyles {
+		nat.MoveBattleStylePreferences = append(nat.MoveBattleStylePreferences, models.NatureMoveBattleStylePreferencesElem{
+			HighHpPreference: f.instance.IntRange(0, 100),
+			LowHpPreference:  f.instance.IntRange(0, 100),
+			MoveBattleStyle:  *f.resource("move-battle-style", s),
+		})
+	}
+
+	return nat
+}
+
+// GenerateStat returns one of the PokéAPI's stats, chosen at random.
+func (f *Faker) GenerateStat() *models.Stat {
+	return f.stat(stats[f.instance.IntRange(0, len(stats)-1)])
+}
+
+// Stats returns every stat, in ID order.
+func (f *Faker) Stats() []*models.Stat {
+	ss := make([]*models.Stat, len(stats))
+	for i, s := range stats {
+		ss[i] = f.stat(s)
+	}
+	return ss
+}
+
+func (f *Faker) stat(s ref) *models.Stat {
+	st := &models.Stat{
+		ID:   s.id,
+		Name: s.name,
+		// Accuracy and evasion only exist in battle, so they don't have a
+		// game index.
+		IsBattleOnly:    s.id > 6,
+		Characteristics: []models.ApiResource{},
+		AffectingMoves: models.StatAffectingMoves{
+			Increase: []models.StatAffectingMovesIncreaseElem{},
+			Decrease: []models.StatAffectingMovesDecreaseElem{},
+		},
+		AffectingNatures: models.StatAffectingNatures{
+			Increase: []models.NamedApiResource{},
+			Decrease: []models.NamedApiResource{},
+		},
+		Names: []models.StatNamesElem{},
+	}
+	if !st.IsBattleOnly {
+		st.GameIndex = s.id
+		// There are 5 characteristics for each stat, with IDs interleaved
+		// between the stats.
+		for i := 0; i < 5; i++ {
+			st.Characteristics = append(st.Characteristics, models.ApiResource{
+				Url: f.url("characteristic", s.id+i*6),
+			})
+		}
+	}
+	if class, ok := statDamageClass[s.id]; ok {
+		st.MoveDamageClass = f.resource("move-damage-class", class)
+	}
+
+	for _, n := range natures {
+		if n.increased == n.decreased {
+			continue
+		}
+		if n.increased == s.id {
+			st.AffectingNatures.Increase = append(st.AffectingNatures.Increase, *f.resource("nature", n.ref))
+		}
+		if n.decreased == s.id {
+			st.AffectingNatures.Decrease = append(st.AffectingNatures.Decrease, *f.resource("nature", n.ref))
+		}
+	}
+
+	for i := f.instance.IntRange(0, 3); i > 0; i-- {
+		st.AffectingMoves.Increase = append(st.AffectingMoves.Increase, models.StatAffectingMovesIncreaseElem{
+			Change: f.instance.IntRange(1, 3),
+			Move:   *f.resource("move", f.pick(moves)),
+		})
+	}
+	for i := f.instance.IntRange(0, 3); i > 0; i-- {
+		st.AffectingMoves.Decrease = append(st.AffectingMoves.Decrease, models.StatAffectingMovesDecreaseElem{
+			Change: -f.instance.IntRange(1, 3),
+			Move:   *f.resource("move", f.pick(moves)),
+		})
+	}
+
+	for _, l := range languages {
+		st.Names = append(st.Names, models.StatNamesElem{
+			Language: *f.resource("language", l),
+			Name:     title(s.name),
+		})
+	}
+
+	return st
+}
+
+// GeneratePokemon returns a Pokémon with a random ID.
+func (f *Faker) GeneratePokemon() *models.Pokemon {
+	return f.Pokemon(f.instance.IntRange(1, maximumPokemonID))
+}
+
+// Pokemon returns a Pokémon with the given ID and a unique, generated name.
+func (f *Faker) Pokemon(id int) *models.Pokemon {
+	name := f.name()
+	p := &models.Pokemon{
+		ID:                     id,
+		Name:                   name,
+		Order:                  id,
+		IsDefault:              true,
+		Height:                 f.instance.IntRange(1, 200),
+		Weight:                 f.instance.IntRange(1, 10000),
+		LocationAreaEncounters: f.url("pokemon", id) + "encounters",
+		Species:                *f.resource("pokemon-species", ref{id, name}),
+		Forms:                  []models.NamedApiResource{*f.resource("pokemon-form", ref{id, name})},
+		GameIndices:            []models.PokemonGameIndicesElem{},
+		HeldItems:              []models.PokemonHeldItemsElem{},
+		Moves:                  []models.PokemonMovesElem{},
+		PastAbilities:          []models.PokemonPastAbilitiesElem{},
+		PastTypes:              []models.PokemonPastTypesElem{},
+	}
+	baseExperience := f.instance.IntRange(36, 390)
+	p.BaseExperience = &baseExperience
+
+	for i, a := range f.sample(abilities, f.instance.IntRange(1, 3)) {
+		p.Abilities = append(p.Abilities, models.PokemonAbilitiesElem{
+			Ability: *f.resource("ability", a),
+			// The third ability of a Pokémon is its hidden ability.
+			IsHidden: i == 2,
+			Slot:     i + 1,
+		})
+	}
+	for i, t := range f.sample(types, f.instance.IntRange(1, 2)) {
+		p.Types = append(p.Types, models.PokemonTypesElem{
+			Slot: i + 1,
+			Type: *f.resource("type", t),
+		})
+	}
+	// Every Pokémon has a base value for each of the six battle stats.
+	for _, s := range stats[:6] {
+		p.Stats = append(p.Stats, models.PokemonStatsElem{
+			BaseStat: f.instance.IntRange(1, 255),
+			Effort:   f.instance.IntRange(0, 3),
+			Stat:     *f.resource("stat", s),
+		})
+	}
+	for _, v := range f.sample(versions, f.instance.IntRange(1, len(versions))) {
+		p.GameIndices = append(p.GameIndices, models.PokemonGameIndicesElem{
+			GameIndex: id,
+			Version:   *f.resource("version", v),
+		})
+	}
+	for _, item := range f.sample(items, f.instance.IntRange(0, 2)) {
+		p.HeldItems = append(p.HeldItems, models.PokemonHeldItemsElem{
+			Item: *f.resource("item", item),
+			VersionDetails: []models.PokemonHeldItemsElemVersionDetailsElem{{
+				Rarity:  f.instance.RandomInt([]int{5, 50, 100}),
+				Version: *f.resource("version", f.pick(versions)),
+			}},
+		})
+	}
+	for _, m := range f.sample(moves, f.instance.IntRange(1, 8)) {
+		method := f.pick(moveLearnMethods)
+		level := 0
+		if method.name == "level-up" {
+			level = f.instance.IntRange(1, 100)
+		}
+		p.Moves = append(p.Moves, models.PokemonMovesElem{
+			Move: *f.resource("move", m),
+			VersionGroupDetails: []models.PokemonMovesElemVersionGroupDetailsElem{{
+				LevelLearnedAt:  level,
+				MoveLearnMethod: *f.resource("move-learn-method", method),
+				VersionGroup:    *f.resource("version-group", f.pick(versionGroups)),
+			}},
+		})
+	}
+
+	f.sprites(reflect.ValueOf(&p.Sprites).Elem(), spritesURL, id, f.instance.Bool())
+
+	return p
+}
+
+// sprites sets every sprite URL in v, a sprites struct of a Pokémon. The URL
+// of each sprite is derived from its path through the JSON fields, for example
+// the "back_shiny" sprite of "versions.generation-i.red-blue" is
+// .../versions/generation-i/red-blue/back/shiny/1.png. Female sprites are only
+// set if the Pokémon looks different depending on its gender.
+func (f *Faker) sprites(v reflect.Value, prefix string, id int, female bool) {
+	for i := 0; i < v.NumField(); i++ {
+		var (
+			field = v.Field(i)
+			name  = strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		)
+		switch field.Kind() {
+		case reflect.Struct:
+			f.sprites(field, prefix+"/"+name, id, female)
+		case reflect.Pointer:
+			if strings.Contains(name, "female") && !female {
+				continue
+			}
+			// Like the PokéAPI, front and default sprites don't have their own
+			// directory.
+			path := prefix
+			for _, part := range strings.Split(name, "_") {
+				if part != "front" && part != "default" {
+					path += "/" + part
+				}
+			}
+			u := fmt.Sprintf("%s/%d.png", path, id)
+			field.Set(reflect.ValueOf(&u))
+		}
+	}
+}
+
+// name generates a unique, pronounceable Pokémon name.
+func (f *Faker) name() string {
+	for {
+		var b strings.Builder
+		for i := f.instance.IntRange(2, 3); i > 0; i-- {
+			b.WriteString(f.instance.RandomString(syllables))
+		}
+		name := b.String()
+		if _, ok := f.names[name]; !ok {
+			f.names[name] = struct{}{}
+			return name
+		}
+	}
+}
+
+func (f *Faker) resource(endpoint string, r ref) *models.NamedApiResource {
+	return &models.NamedApiResource{Name: r.name, Url: f.url(endpoint, r.id)}
+}
+
+// url returns the URL of a resource, with a trailing slash like the PokéAPI.
+func (f *Faker) url(endpoint string, id int) string {
+	return fmt.Sprintf("%s/%s/%d/", f.baseURL, endpoint, id)
+}
+
+func (f *Faker) pick(refs []ref) ref {
+	return refs[f.instance.IntRange(0, len(refs)-1)]
+}
+
+// sample returns n distinct refs, chosen at random.
+func (f *Faker) sample(refs []ref, n int) []ref {
+	shuffled := append([]ref(nil), refs...)
+	f.instance.ShuffleAnySlice(shuffled)
+	return shuffled[:n]
+}
+
+// title converts a resource name, such as "special-attack", into a display
+// name, such as "Special Attack".
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
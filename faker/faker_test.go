@@ -0,0 +1,80 @@
+package faker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaker_Deterministic(t *testing.T) {
+	a := NewFakerWithOptions(Options{Seed: 42})
+	b := NewFakerWithOptions(Options{Seed: 42})
+
+	require.Equal(t, a.Pokemon(1), b.Pokemon(1))
+	require.Equal(t, a.GeneratePokemon(), b.GeneratePokemon())
+	require.Equal(t, a.Natures(), b.Natures())
+	require.Equal(t, a.Stats(), b.Stats())
+}
+
+func TestFaker_Pokemon(t *testing.T) {
+	f := NewFakerWithOptions(Options{Seed: 1, BaseURL: "http://localhost:8080/api/v2/"})
+
+	names := make(map[string]bool)
+	for id := 1; id <= 50; id++ {
+		p := f.Pokemon(id)
+		require.Equal(t, id, p.ID)
+		require.False(t, names[p.Name], "duplicate name %q", p.Name)
+		names[p.Name] = true
+
+		require.Equal(t, fmt.Sprintf("http://localhost:8080/api/v2/pokemon-species/%d/", id), p.Species.Url)
+		require.Equal(t, fmt.Sprintf("http://localhost:8080/api/v2/pokemon/%d/encounters", id), p.LocationAreaEncounters)
+		require.NotNil(t, p.Sprites.FrontDefault)
+		require.NotEmpty(t, p.Types)
+		require.NotEmpty(t, p.Abilities)
+		require.NotEmpty(t, p.Moves)
+
+		require.Len(t, p.Stats, 6)
+		for i, s := range p.Stats {
+			require.Equal(t, stats[i].name, s.Stat.Name)
+			require.Equal(t, fmt.Sprintf("http://localhost:8080/api/v2/stat/%d/", i+1), s.Stat.Url)
+			require.GreaterOrEqual(t, s.BaseStat, 1)
+			require.LessOrEqual(t, s.BaseStat, 255)
+		}
+	}
+}
+
+func TestFaker_NaturesAndStats(t *testing.T) {
+	f := NewFaker()
+
+	ns := f.Natures()
+	require.Len(t, ns, 25)
+	ss := f.Stats()
+	require.Len(t, ss, 8)
+
+	increasedBy := make(map[string][]string)
+	for _, n := range ns {
+		if n.IncreasedStat == nil {
+			require.Nil(t, n.DecreasedStat)
+			continue
+		}
+		require.NotEqual(t, n.IncreasedStat.Name, n.DecreasedStat.Name)
+		require.NotNil(t, n.LikesFlavor)
+		require.NotNil(t, n.HatesFlavor)
+		increasedBy[n.IncreasedStat.Name] = append(increasedBy[n.IncreasedStat.Name], n.Name)
+	}
+
+	for _, s := range ss {
+		var got []string
+		for _, n := range s.AffectingNatures.Increase {
+			got = append(got, n.Name)
+		}
+		require.Equal(t, increasedBy[s.Name], got, s.Name)
+
+		if s.IsBattleOnly {
+			require.Empty(t, s.Characteristics)
+		} else {
+			require.Len(t, s.Characteristics, 5)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package pokedex
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// listPage fetches the page of a resource list between start and end, then
+// hydrates each of its results using get. The page records the total count of
+// the resource list, even if hydration fails.
+func listPage[T any](ctx context.Context, c *Client, resource string, start, end uint, partial bool, get func(ctx context.Context, resource string) (T, error)) (iterator.Page[T], error) {
+	ctx, span := c.telemetry.tracer.Start(ctx, "pokedex.list.page", trace.WithAttributes(
+		attribute.String("pokedex.resource", resource),
+		attribute.Int("pokedex.offset", int(start)),
+		attribute.Int("pokedex.limit", int(end-start)),
+	))
+	defer span.End()
+
+	c.logger.DebugContext(ctx, "list page started",
+		slog.String("resource", resource),
+		slog.Uint64("offset", uint64(start)),
+		slog.Uint64("limit", uint64(end-start)),
+	)
+
+	resourceList, err := c.fetchResourceList(ctx, resource, start, end-start)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return iterator.Page[T]{Total: -1}, err
+	}
+
+	results, err := hydrate(ctx, resource, resourceList, partial, get)
+	span.SetAttributes(attribute.Int("pokedex.results", len(results)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	attrs := []any{
+		slog.String("resource", resource),
+		slog.Uint64("offset", uint64(start)),
+		slog.Int("results", len(results)),
+		slog.Int("count", resourceList.Count),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	c.logger.DebugContext(ctx, "list page finished", attrs...)
+
+	return iterator.Page[T]{Results: results, Total: resourceList.Count}, err
+}
+
+// hydrate follows up each result of a NamedApiResourceList with a request for
+// the full resource, using the provided get function.
+//
+// As we know the number of results from the NamedApiResourceList we can create
+// slices that size and each goroutine updates its own memory based on the
+// index i. Every failure is reported via a *PageError rather than just the
+// first one. If partial is true the successfully fetched resources are
+// returned alongside the *PageError, otherwise the page is discarded.
+func hydrate[T any](ctx context.Context, resource string, list *models.NamedApiResourceList, partial bool, get func(ctx context.Context, resource string) (T, error)) ([]T, error) {
+	var (
+		wg      sync.WaitGroup
+		names   = make([]string, len(list.Results))
+		results = make([]T, len(list.Results))
+		errs    = make([]error, len(list.Results))
+	)
+	for i, item := range list.Results {
+		// Results of unnamed endpoints only have a URL, so they're fetched
+		// by the ID at the end of it.
+		name, err := refName(item)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		names[i] = name
+
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i], errs[i] = get(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	pageErr := &PageError{Resource: resource}
+	hydrated := make([]T, 0, len(results))
+	for i, err := range errs {
+		if err != nil {
+			pageErr.Errors = append(pageErr.Errors, ResourceError{
+				Name: names[i],
+				Err:  asSDKError(err),
+			})
+			continue
+		}
+		hydrated = append(hydrated, results[i])
+	}
+
+	if len(pageErr.Errors) == 0 {
+		return hydrated, nil
+	}
+	if partial {
+		pageErr.partial = true
+		return hydrated, pageErr
+	}
+	return nil, pageErr
+}
+
+// asSDKError returns err as an *SDKError, wrapping it if it isn't one
+// already.
+func asSDKError(err error) *SDKError {
+	var sdkErr *SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr
+	}
+	return NewError(KindInternal, err, nil)
+}
@@ -0,0 +1,16 @@
+// Package defaults holds the default settings shared by the SDK client, its
+// fake server and the snapshot exporter.
+package defaults
+
+const (
+	// PageSize is the number of resources per page of a named resource list
+	// when no limit is given, matching the PokéAPI's own default.
+	PageSize = 20
+	// CrawlPageSize is the number of resources per page of a named resource
+	// list when walking every resource of an endpoint, such as when
+	// prefetching or exporting a snapshot, so fewer list requests are made.
+	CrawlPageSize = 100
+	// Concurrency is the maximum number of requests made at once when
+	// fetching many resources.
+	Concurrency = 8
+)
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 type Store interface {
 	Set(url string, body []byte)
 	Get(url string) (body []byte, ok bool)
+	Delete(url string)
+	Clear()
 	Close()
 }
 
@@ -25,26 +28,56 @@ type Store interface {
 type Cache struct {
 	mu    sync.RWMutex
 	cache *ristretto.Cache
+	// keys maps each URL in the cache to its entry, so records can be deleted
+	// by prefix. Entries are removed by ristretto's OnExit hook once they're
+	// evicted, rejected, expired or replaced. The hook may run while mu is
+	// held, so keys is guarded by its own lock.
+	km   sync.Mutex
+	keys map[string]*entry
 	// ttl is how long a cache entry will be available after being set.
 	// By default the TTL for all API responses is set to 10 minutes.
 	ttl time.Duration
 }
 
+// entry is the value stored in ristretto for each URL. The URL is kept with
+// the body so the OnExit hook knows which key to forget.
+type entry struct {
+	url  string
+	body []byte
+	// expires is when the entry's TTL ends, or zero if it never does.
+	expires time.Time
+}
+
 type CacheOptions struct {
 	MaximumSize int64
 	TTL         time.Duration
-	// debug determines whether cache statistics are kept during the cache's
-	// lifecycle. This is useful for testing, as we can assert the result of
-	// specific operations against the cache.
-	//
-	// Cache metrics could be potentially exposed to clients if there's a
-	// suitable use-case. For example, users may want to observe the cache
-	// hit rate & validate that against some hypothetical API limits, etc.
-	debug bool
+	// Metrics determines whether cache statistics are kept during the cache's
+	// lifecycle. Users may want to observe the cache hit rate & validate that
+	// against the PokéAPI's fair use policy. Keeping statistics has a small
+	// performance cost, so they're disabled by default.
+	Metrics bool
+}
+
+// Stats is a snapshot of the cache's statistics. All values are zero if the
+// cache was created without Metrics enabled.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	KeysAdded   uint64
+	KeysUpdated uint64
+	KeysEvicted uint64
+	// CostAdded and CostEvicted are the total size (in bytes) of the records
+	// added to and evicted from the cache.
+	CostAdded   uint64
+	CostEvicted uint64
 }
 
 // NewCache instantiates an in-memory Ristretto cache for PokéAPI responses.
 func NewCache(options CacheOptions) (*Cache, error) {
+	c := &Cache{
+		keys: make(map[string]*entry),
+		ttl:  options.TTL,
+	}
 	rst, err := ristretto.NewCache(&ristretto.Config{
 		// MaxCost is the maximum size (in bytes) of the cache.
 		MaxCost: options.MaximumSize,
@@ -53,12 +86,32 @@ func NewCache(options CacheOptions) (*Cache, error) {
 		// Ristretto recommend setting BufferItems to 64 for "generally good
 		// performance".
 		BufferItems: 64,
-		Metrics:     options.debug,
+		// Each record's cost is the size of its body, so ristretto's own
+		// bookkeeping overhead shouldn't count towards MaxCost.
+		IgnoreInternalCost: true,
+		Metrics:            options.Metrics,
+		OnExit:             c.forget,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &Cache{cache: rst, ttl: options.TTL}, nil
+	c.cache = rst
+	return c, nil
+}
+
+// forget removes an entry's URL from keys once ristretto no longer holds it.
+// The URL is only removed if it still refers to the same entry, since the
+// previous value of an updated URL exits after the new one is recorded.
+func (c *Cache) forget(value interface{}) {
+	e, ok := value.(*entry)
+	if !ok {
+		return
+	}
+	c.km.Lock()
+	defer c.km.Unlock()
+	if c.keys[e.url] == e {
+		delete(c.keys, e.url)
+	}
 }
 
 // Set adds a PokeAPI response to the cache. There is no guarantee a call to
@@ -70,7 +123,21 @@ func (c *Cache) Set(url string, body []byte) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.cache.SetWithTTL(url, body, 0, c.ttl)
+	e := &entry{url: url, body: body}
+	if c.ttl > 0 {
+		e.expires = time.Now().Add(c.ttl)
+	}
+	// The URL is recorded before the entry is added, so it's forgotten even
+	// if ristretto evicts or rejects the entry straight away.
+	c.km.Lock()
+	c.keys[url] = e
+	c.km.Unlock()
+
+	// The cost of each record is its size, so MaximumSize bounds the number
+	// of bytes held in the cache.
+	if !c.cache.SetWithTTL(url, e, int64(len(body)), c.ttl) {
+		c.forget(e)
+	}
 	// Wait for the value we've just added to the cache to pass through any
 	// internal ristretto buffers. This ensures once we release the write lock
 	// the new value is immediately available to subsequent readers.
@@ -81,11 +148,80 @@ func (c *Cache) Set(url string, body []byte) {
 // found, the value and a boolean (true) are returned. If the cache does not
 // contain a record a given response, value is nil and the boolean false.
 func (c *Cache) Get(url string) ([]byte, bool) {
-	body, ok := c.cache.Get(url)
+	value, ok := c.cache.Get(url)
 	if !ok {
 		return nil, false
 	}
-	return body.([]byte), ok
+	return value.(*entry).body, ok
+}
+
+// Delete removes a PokéAPI response from the cache, if present.
+func (c *Cache) Delete(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.Del(url)
+	// Like Set, wait for the delete to be processed, so the statistics
+	// reflect it once the lock is released.
+	c.cache.Wait()
+}
+
+// DeletePrefix removes every PokéAPI response whose URL starts with prefix
+// from the cache, returning the number of responses removed. Responses that
+// had already been evicted or had expired aren't counted.
+func (c *Cache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var (
+		urls []string
+		n    int
+	)
+	c.km.Lock()
+	for url, e := range c.keys {
+		if strings.HasPrefix(url, prefix) {
+			urls = append(urls, url)
+			if e.expires.IsZero() || now.Before(e.expires) {
+				n++
+			}
+		}
+	}
+	c.km.Unlock()
+
+	// Deleting calls the OnExit hook, which takes km, so the URLs are
+	// deleted once it's been released.
+	for _, url := range urls {
+		c.cache.Del(url)
+	}
+	c.cache.Wait()
+	return n
+}
+
+// Clear removes every PokéAPI response from the cache, and resets its
+// statistics.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.Clear()
+	c.km.Lock()
+	c.keys = make(map[string]*entry)
+	c.km.Unlock()
+}
+
+// Stats returns a snapshot of the cache's statistics.
+func (c *Cache) Stats() Stats {
+	m := c.cache.Metrics
+	return Stats{
+		Hits:        m.Hits(),
+		Misses:      m.Misses(),
+		KeysAdded:   m.KeysAdded(),
+		KeysUpdated: m.KeysUpdated(),
+		KeysEvicted: m.KeysEvicted(),
+		CostAdded:   m.CostAdded(),
+		CostEvicted: m.CostEvicted(),
+	}
 }
 
 // Close gracefully shutsdown the cache.
@@ -12,7 +12,7 @@ func TestCache(t *testing.T) {
 	c, err := NewCache(CacheOptions{
 		MaximumSize: 1 << 27,
 		TTL:         10 * time.Minute,
-		debug:       true,
+		Metrics:     true,
 	})
 	require.NoError(t, err)
 	defer c.Close()
@@ -49,3 +49,94 @@ func TestCache(t *testing.T) {
 	require.Equal(t, uint64(16), c.cache.Metrics.Hits())
 	require.Equal(t, uint64(1), c.cache.Metrics.Misses())
 }
+
+func TestCacheDelete(t *testing.T) {
+	c, err := NewCache(CacheOptions{
+		MaximumSize: 1 << 20,
+		TTL:         10 * time.Minute,
+		Metrics:     true,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	c.Set("https://example.com/pokemon/1", []byte("bulbasaur"))
+	c.Set("https://example.com/pokemon/2", []byte("ivysaur"))
+	c.Set("https://example.com/pokemon-species/1", []byte("bulbasaur"))
+	c.Set("https://example.com/stat/1", []byte("hp"))
+
+	stats := c.Stats()
+	require.Equal(t, uint64(4), stats.KeysAdded)
+	require.Equal(t, uint64(len("bulbasaur")*2+len("ivysaur")+len("hp")), stats.CostAdded)
+
+	c.Delete("https://example.com/stat/1")
+	_, ok := c.Get("https://example.com/stat/1")
+	require.False(t, ok)
+	// The statistics include the deleted record straight away.
+	stats = c.Stats()
+	require.Equal(t, uint64(1), stats.KeysEvicted)
+	require.Equal(t, uint64(len("hp")), stats.CostEvicted)
+
+	// Only records under the prefix are removed.
+	n := c.DeletePrefix("https://example.com/pokemon/")
+	require.Equal(t, 2, n)
+	stats = c.Stats()
+	require.Equal(t, uint64(3), stats.KeysEvicted)
+	require.Equal(t, uint64(len("hp")+len("bulbasaur")+len("ivysaur")), stats.CostEvicted)
+	_, ok = c.Get("https://example.com/pokemon/1")
+	require.False(t, ok)
+	_, ok = c.Get("https://example.com/pokemon-species/1")
+	require.True(t, ok)
+
+	c.Clear()
+	_, ok = c.Get("https://example.com/pokemon-species/1")
+	require.False(t, ok)
+}
+
+func TestCacheKeys(t *testing.T) {
+	c, err := NewCache(CacheOptions{
+		MaximumSize: 100,
+		TTL:         10 * time.Minute,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Only around 10 of these records fit in the cache, so most are evicted
+	// or rejected.
+	for i := 0; i < 50; i++ {
+		c.Set(fmt.Sprintf("https://example.com/pokemon/%d", i), []byte("0123456789"))
+	}
+
+	c.km.Lock()
+	urls := make([]string, 0, len(c.keys))
+	for url := range c.keys {
+		urls = append(urls, url)
+	}
+	c.km.Unlock()
+
+	// Evicted URLs are forgotten, so every URL left is still cached.
+	require.LessOrEqual(t, len(urls), 10)
+	for _, url := range urls {
+		_, ok := c.Get(url)
+		require.True(t, ok, url)
+	}
+	require.Equal(t, len(urls), c.DeletePrefix("https://example.com/pokemon/"))
+
+	c.km.Lock()
+	require.Empty(t, c.keys)
+	c.km.Unlock()
+}
+
+func TestCacheDeletePrefixExpired(t *testing.T) {
+	c, err := NewCache(CacheOptions{
+		MaximumSize: 1 << 20,
+		TTL:         50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	c.Set("https://example.com/pokemon/1", []byte("bulbasaur"))
+	time.Sleep(100 * time.Millisecond)
+
+	// The record has expired, so it isn't counted as removed.
+	require.Equal(t, 0, c.DeletePrefix("https://example.com/pokemon/"))
+}
@@ -0,0 +1,31 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+)
+
+// concat is the Iterator returned by Concat.
+type concat[T any] struct {
+	its []Iterator[T]
+}
+
+// Concat returns an Iterator over the pages of each of its, in turn, which can
+// be ranged over with Pages or All. An error from one of its, other than
+// EndOfIterator, is returned without moving on to the next, so the failed page
+// can be retried as it would be with that iterator alone.
+func Concat[T any](its ...Iterator[T]) Iterator[T] {
+	return &concat[T]{its: its}
+}
+
+func (it *concat[T]) Next(ctx context.Context) ([]T, error) {
+	for len(it.its) > 0 {
+		page, err := it.its[0].Next(ctx)
+		if errors.Is(err, EndOfIterator) {
+			it.its = it.its[1:]
+			continue
+		}
+		return page, err
+	}
+	return nil, EndOfIterator
+}
@@ -0,0 +1,117 @@
+package iterator
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// ConcurrentPaginator is a Paginator that is safe to use by multiple
+// goroutines simultaneously, for example a pool of workers draining a single
+// list. Each call to Next claims the next page's offset range before fetching
+// it, so pages are fetched concurrently and each is returned exactly once.
+//
+// A page that fails without any results is claimed again by a later call to
+// Next, so it isn't skipped. Pages are always claimed in ranges of the page
+// size, so the worker must return full pages until the end of the collection.
+type ConcurrentPaginator[T any] struct {
+	mu     sync.Mutex
+	done   bool
+	limit  uint
+	offset uint
+	end    uint
+	total  int
+	// retry holds the offsets of failed pages, which are claimed before any
+	// new page.
+	retry []uint
+
+	worker func(ctx context.Context, start, end uint) (Page[T], error)
+}
+
+// Concurrent returns a ConcurrentPaginator continuing from the Paginator's
+// position, with the same page size, Options.Max and worker. Any pages read
+// ahead are discarded, and the Paginator shouldn't be used afterwards. The
+// ConcurrentPaginator doesn't read ahead or report Options.Progress.
+func (it *Paginator[T]) Concurrent() *ConcurrentPaginator[T] {
+	it.Stop()
+	return &ConcurrentPaginator[T]{
+		done:   it.done,
+		limit:  it.limit,
+		offset: it.offset,
+		end:    it.end,
+		total:  it.total,
+		worker: it.worker,
+	}
+}
+
+// Next claims and fetches the next page. Once every page has been claimed it
+// returns EndOfIterator, although pages claimed by other goroutines may still
+// be being fetched.
+func (it *ConcurrentPaginator[T]) Next(ctx context.Context) ([]T, error) {
+	start, ok := it.claim()
+	if !ok {
+		return nil, EndOfIterator
+	}
+
+	p, err := fetchPage(ctx, it.worker, start, it.limit, it.end)
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if p.Total >= 0 {
+		it.total = p.Total
+	}
+	if err != nil && (len(p.Results) > 0 || partial(err)) {
+		// A partial page is treated as consumed, like Paginator.Next.
+		return p.Results, err
+	}
+	if err != nil {
+		it.retry = append(it.retry, start)
+		return nil, err
+	}
+	if len(p.Results) == 0 {
+		it.done = true
+		return nil, EndOfIterator
+	}
+	return p.Results, nil
+}
+
+// claim returns the offset of the next page to fetch, or false if there are
+// none left.
+func (it *ConcurrentPaginator[T]) claim() (uint, bool) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if len(it.retry) > 0 {
+		start := it.retry[0]
+		it.retry = it.retry[1:]
+		return start, true
+	}
+	if it.done || (it.end > 0 && it.offset >= it.end) {
+		return 0, false
+	}
+	start := it.offset
+	it.offset += it.limit
+	return start, true
+}
+
+// Total returns the number of results in the whole collection, or -1 if it
+// isn't known.
+func (it *ConcurrentPaginator[T]) Total() int {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.total
+}
+
+// Pages returns an iterator over the pages claimed by the caller, as
+// described by Paginator.Pages. Each goroutine can range over its own call to
+// Pages, and together they see every page once.
+func (it *ConcurrentPaginator[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return pages(ctx, it.Next)
+}
+
+// All returns an iterator over each result of the pages claimed by the
+// caller, as described by Paginator.All.
+func (it *ConcurrentPaginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return results(it.Pages(ctx))
+}
@@ -0,0 +1,118 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentPaginator(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		ints       = ints(1000)
+		inducedErr = errors.New("something broke")
+		failed     atomic.Bool
+	)
+
+	it := NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		// The page at 500 fails the first time it's fetched.
+		if start == 500 && failed.CompareAndSwap(false, true) {
+			return nil, inducedErr
+		}
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+		return ints[start:min(end, uint(len(ints)))], nil
+	})
+	ns, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[:10], ns)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		got  []int
+		errs []error
+		c    = it.Concurrent()
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				page, err := c.Next(ctx)
+				if errors.Is(err, EndOfIterator) {
+					return
+				}
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				}
+				got = append(got, page...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every result after the first page is returned exactly once, including
+	// those of the page that failed and was retried.
+	sort.Ints(got)
+	require.Equal(t, ints[10:], got)
+	require.Equal(t, []error{inducedErr}, errs)
+
+	_, err = c.Next(ctx)
+	require.ErrorIs(t, err, EndOfIterator)
+}
+
+func TestConcurrentPaginatorMax(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		ints  = ints(100)
+		calls atomic.Int64
+	)
+
+	it, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, Offset: 5, Max: 42}, func(ctx context.Context, start, end uint) ([]int, error) {
+		calls.Add(1)
+		return ints[start:min(end, uint(len(ints)))], nil
+	})
+	require.NoError(t, err)
+	c := it.Concurrent()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		sum  atomic.Int64
+		errs []error
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n, err := range c.All(ctx) {
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					continue
+				}
+				sum.Add(int64(n))
+			}
+		}()
+	}
+	wg.Wait()
+	require.Empty(t, errs)
+
+	var want int
+	for _, n := range ints[5:47] {
+		want += n
+	}
+	require.Equal(t, int64(want), sum.Load())
+	// No page is requested past the Max.
+	require.Equal(t, int64(5), calls.Load())
+}
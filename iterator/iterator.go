@@ -3,35 +3,143 @@ package iterator
 import (
 	"context"
 	"errors"
+	"fmt"
+	"iter"
 )
 
 var EndOfIterator = errors.New("iterator finished")
 
+// Iterator iterates over a collection of T one page at a time. It's
+// implemented by Paginator and ConcurrentPaginator over a paginated
+// collection, Slice over values held in memory, for example in tests, and
+// Concat over several iterators in turn. Pages and All range over any
+// Iterator.
 type Iterator[T any] interface {
-	// Next runs the upcoming iteration of the iterator.
-	// The worker function runs, and returns a collection of T.
-	// Before returning, the iterator's offset is updated so it is ready for
-	// the next iteration.
-	Next(ctx context.Context) ([]T, any)
+	// Next returns the upcoming page of the iterator, moving it on so it's
+	// ready for the next iteration. Once there are no pages left it returns
+	// EndOfIterator.
+	Next(ctx context.Context) ([]T, error)
 }
 
+var (
+	_ Iterator[any] = (*Paginator[any])(nil)
+	_ Iterator[any] = (*ConcurrentPaginator[any])(nil)
+	_ Iterator[any] = (*Slice[any])(nil)
+)
+
 // Iterator is an abstraction over a paginated collection.
-// It is not safe to used by multiple goroutines simultaneously.
+// It is not safe to used by multiple goroutines simultaneously. See
+// Concurrent for a variant that is.
 type Paginator[T any] struct {
 	done   bool
 	limit  uint
 	offset uint
+	// end is the offset the iteration ends at, or 0 if it continues until
+	// the end of the collection.
+	end uint
+	// origin is the offset the iteration started from, or was last moved to
+	// by Seek. Progress is reported relative to it.
+	origin uint
+	// name identifies the collection being paginated in tokens.
+	name string
+	// readAhead is the number of pages fetched in the background ahead of
+	// the caller. See readahead.go.
+	readAhead int
+	// ctx is the context the Paginator was created with, which bounds the
+	// lifetime of the background fetches.
+	ctx     context.Context
+	pages   chan fetched[T]
+	stop    context.CancelFunc
+	stopped chan struct{}
+	// total is the number of results in the collection, or -1 if it isn't
+	// known yet. See page.go.
+	total    int
+	progress func(Progress)
+
+	worker func(ctx context.Context, start, end uint) (Page[T], error)
+}
 
-	worker func(ctx context.Context, start, end uint) ([]T, error)
+type Options struct {
+	// Limit is the number of results requested per page.
+	Limit uint
+	// Offset is the offset of the first page, so the start of the collection
+	// can be skipped.
+	Offset uint
+	// Max is the maximum number of results returned, starting from Offset.
+	// If zero, the iteration continues until the end of the collection.
+	Max uint
+	// Name identifies the collection being paginated, for example "pokemon".
+	// It's recorded in the Paginator's tokens, so a token can't be used to
+	// resume a different collection.
+	Name string
+	// Token resumes pagination from a token returned by Paginator.Token. The
+	// token must have been created with the same Name and Limit. It restores
+	// the Offset and Max the Paginator was created with, so they needn't be
+	// set again, but if Max is set it must end at the same offset.
+	Token string
+	// ReadAhead is the number of pages fetched in the background while the
+	// caller processes the current page, so it doesn't wait on every page
+	// boundary. If zero, each page is only fetched when Next is called. The
+	// background fetches use the context the Paginator is created with, so
+	// they stop when it's cancelled.
+	ReadAhead int
+	// Progress is called each time Next returns a page, with the position of
+	// the Paginator in the collection.
+	Progress func(Progress)
 }
 
 func NewPaginator[T any](ctx context.Context, limit uint, worker func(ctx context.Context, start, end uint) ([]T, error)) *Paginator[T] {
-	return &Paginator[T]{
-		done:   false,
-		limit:  limit,
-		offset: 0,
-		worker: worker,
+	// Without a token the options can't be invalid.
+	it, _ := NewPaginatorWithOptions(ctx, Options{Limit: limit}, worker)
+	return it
+}
+
+// NewPaginatorWithOptions creates a Paginator with the provided settings. An
+// error wrapping ErrInvalidToken is returned if Options.Token can't be used
+// to resume pagination.
+func NewPaginatorWithOptions[T any](ctx context.Context, options Options, worker func(ctx context.Context, start, end uint) ([]T, error)) (*Paginator[T], error) {
+	return NewPaginatorWithPages(ctx, options, func(ctx context.Context, start, end uint) (Page[T], error) {
+		result, err := worker(ctx, start, end)
+		return Page[T]{Results: result, Total: -1}, err
+	})
+}
+
+// NewPaginatorWithPages creates a Paginator like NewPaginatorWithOptions, with
+// a worker that also reports metadata about the collection, such as its
+// total size.
+func NewPaginatorWithPages[T any](ctx context.Context, options Options, worker func(ctx context.Context, start, end uint) (Page[T], error)) (*Paginator[T], error) {
+	it := &Paginator[T]{
+		done:     false,
+		limit:    options.Limit,
+		offset:   options.Offset,
+		origin:   options.Offset,
+		name:     options.Name,
+		total:    -1,
+		progress: options.Progress,
+		worker:   worker,
+
+		readAhead: options.ReadAhead,
+		ctx:       ctx,
+	}
+	if options.Max > 0 {
+		it.end = options.Offset + options.Max
+	}
+	if options.Token != "" {
+		c, err := parseToken(options.Token)
+		if err != nil {
+			return nil, err
+		}
+		if c.Name != options.Name || c.Limit != options.Limit {
+			return nil, fmt.Errorf("%w: token is for %q with a page size of %d, not %q with a page size of %d",
+				ErrInvalidToken, c.Name, c.Limit, options.Name, options.Limit)
+		}
+		if options.Max > 0 && it.end != c.End {
+			return nil, fmt.Errorf("%w: token ends at offset %d, not %d",
+				ErrInvalidToken, c.End, it.end)
+		}
+		it.offset, it.origin, it.end, it.done = c.Offset, c.Origin, c.End, c.Done
 	}
+	return it, nil
 }
 
 func (it *Paginator[T]) Next(ctx context.Context) ([]T, error) {
@@ -41,15 +149,175 @@ func (it *Paginator[T]) Next(ctx context.Context) ([]T, error) {
 
 	start := it.offset
 
-	result, err := it.worker(ctx, start, start+it.limit)
+	var (
+		p   Page[T]
+		err error
+	)
+	if it.readAhead > 0 {
+		p, err = it.nextReadAhead(ctx)
+	} else {
+		p, err = it.fetch(ctx, start)
+	}
+	result := p.Results
+	if p.Total >= 0 {
+		it.total = p.Total
+	}
+	if err != nil && (len(result) > 0 || partial(err)) {
+		// The worker returned a partial page alongside an error. The page is
+		// treated as consumed, even if none of its results were fetched, so
+		// the next iteration doesn't hand the caller the same results again.
+		it.offset = it.following(start, result, err)
+		it.report(start)
+		return result, err
+	}
 	if err != nil {
+		// Any pages read ahead are discarded, so the failed page is retried
+		// by the next iteration.
+		it.Stop()
 		return nil, err
 	}
 	if len(result) == 0 {
 		it.done = true
+		it.Stop()
 		return nil, EndOfIterator
 	}
 
-	it.offset += uint(len(result))
+	it.offset = it.following(start, result, err)
+	it.report(start)
 	return result, nil
 }
+
+// fetch runs the worker for the page at start. The page is cut short at the
+// Paginator's end, if it has one.
+func (it *Paginator[T]) fetch(ctx context.Context, start uint) (Page[T], error) {
+	return fetchPage(ctx, it.worker, start, it.limit, it.end)
+}
+
+// fetchPage runs worker for the page of size limit at start, cut short at end
+// unless end is 0.
+func fetchPage[T any](ctx context.Context, worker func(ctx context.Context, start, end uint) (Page[T], error), start, limit, end uint) (Page[T], error) {
+	stop := start + limit
+	if end > 0 {
+		if start >= end {
+			return Page[T]{Results: []T{}, Total: -1}, nil
+		}
+		stop = min(stop, end)
+	}
+
+	p, err := worker(ctx, start, stop)
+	if uint(len(p.Results)) > stop-start {
+		p.Results = p.Results[:stop-start]
+	}
+	return p, err
+}
+
+// Seek moves the Paginator to offset, so the next page starts there, even if
+// the iteration had finished. Any pages read ahead are discarded. If
+// Options.Max was set, the iteration still ends Max results after
+// Options.Offset.
+func (it *Paginator[T]) Seek(offset uint) {
+	it.Stop()
+	it.offset = offset
+	it.origin = offset
+	it.done = false
+}
+
+// following returns the offset of the page after the page at start.
+func (it *Paginator[T]) following(start uint, result []T, err error) uint {
+	if err != nil {
+		return start + it.limit
+	}
+	return start + uint(len(result))
+}
+
+// Pages returns an iterator over the remaining pages, for use with range:
+//
+//	for page, err := range it.Pages(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// Pages are only fetched as the loop needs them, so breaking out of the loop
+// stops any further requests. A page returned alongside an error, such as a
+// partial page, is yielded with that error and iteration continues, including
+// a partial page without any results whose error is a PartialError. Any other
+// error is yielded once and ends the iteration.
+func (it *Paginator[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		// If the loop ends early, don't keep reading ahead pages it won't use.
+		defer it.Stop()
+		pages(ctx, it.Next)(yield)
+	}
+}
+
+// Pages returns an iterator over the remaining pages of it, as described by
+// Paginator.Pages. If it has its own Pages method, such as a Paginator, that
+// is used instead.
+func Pages[T any](ctx context.Context, it Iterator[T]) iter.Seq2[[]T, error] {
+	if p, ok := it.(interface {
+		Pages(ctx context.Context) iter.Seq2[[]T, error]
+	}); ok {
+		return p.Pages(ctx)
+	}
+	return pages(ctx, it.Next)
+}
+
+// All returns an iterator over each remaining result of it, as described by
+// Paginator.All.
+func All[T any](ctx context.Context, it Iterator[T]) iter.Seq2[T, error] {
+	return results(Pages(ctx, it))
+}
+
+// pages returns an iterator over the pages returned by next, as described by
+// Paginator.Pages.
+func pages[T any](ctx context.Context, next func(ctx context.Context) ([]T, error)) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		for {
+			page, err := next(ctx)
+			if errors.Is(err, EndOfIterator) {
+				return
+			}
+			if !yield(page, err) || (err != nil && len(page) == 0 && !partial(err)) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over each remaining result, for use with range:
+//
+//	for pokemon, err := range it.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// It behaves like Pages, except the results of each page are yielded one at a
+// time. An error is yielded with the zero value of T, after any results it
+// was returned with.
+func (it *Paginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return results(it.Pages(ctx))
+}
+
+// results returns an iterator over each result of the pages of seq, as
+// described by Paginator.All.
+func results[T any](seq iter.Seq2[[]T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range seq {
+			for _, v := range page {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if err != nil {
+				var zero T
+				if !yield(zero, err) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -102,3 +103,641 @@ func ints(count int) []int {
 	}
 	return ns
 }
+
+func TestPaginatorPartialPage(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		ints       = ints(20)
+		inducedErr = errors.New("something broke")
+	)
+
+	it := NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+
+		// Drop the first result of the first page, as if it failed to be
+		// fetched.
+		if start == 0 {
+			return ints[1:end], inducedErr
+		}
+
+		return ints[start:end], nil
+	})
+
+	ns, err := it.Next(ctx)
+	require.ErrorIs(t, err, inducedErr)
+	require.Equal(t, []int{2, 3, 4, 5, 6, 7, 8, 9, 10}, ns)
+
+	// The partial page is consumed, so the iterator moves onto the next page.
+	ns, err = it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, ns)
+
+	ns, err = it.Next(ctx)
+	require.ErrorIs(t, err, EndOfIterator)
+	require.Empty(t, ns)
+}
+
+// partialErr is returned by a worker alongside a page that was consumed.
+type partialErr struct{}
+
+func (partialErr) Error() string { return "some results failed" }
+func (partialErr) Partial() bool { return true }
+
+func TestPaginatorEmptyPartialPage(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		ints = ints(30)
+	)
+
+	for _, readAhead := range []int{0, 1} {
+		it, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, ReadAhead: readAhead}, func(ctx context.Context, start, end uint) ([]int, error) {
+			if start >= uint(len(ints)) {
+				return []int{}, nil
+			}
+			// Every result of the second page fails to be fetched.
+			if start == 10 {
+				return []int{}, partialErr{}
+			}
+			return ints[start:end], nil
+		})
+		require.NoError(t, err)
+
+		// The empty page is consumed, so iteration continues past it.
+		var (
+			ns   []int
+			errs []error
+		)
+		for n, err := range it.All(ctx) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			ns = append(ns, n)
+		}
+		require.Equal(t, []error{partialErr{}}, errs)
+		require.Equal(t, append(ints[:10:10], ints[20:]...), ns)
+	}
+}
+
+func TestPaginatorAll(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		ints  = ints(55)
+		calls int
+	)
+
+	newPaginator := func() *Paginator[int] {
+		return NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+			calls++
+			if start >= uint(len(ints)) {
+				return []int{}, nil
+			}
+			return ints[start:min(end, uint(len(ints)))], nil
+		})
+	}
+
+	var got []int
+	for n, err := range newPaginator().All(ctx) {
+		require.NoError(t, err)
+		got = append(got, n)
+	}
+	require.Equal(t, ints, got)
+
+	// Breaking out of the loop stops any further pages being fetched.
+	calls = 0
+	for n := range newPaginator().All(ctx) {
+		if n == 15 {
+			break
+		}
+	}
+	require.Equal(t, 2, calls)
+
+	var pages int
+	for page, err := range newPaginator().Pages(ctx) {
+		require.NoError(t, err)
+		require.NotEmpty(t, page)
+		pages++
+	}
+	require.Equal(t, 6, pages)
+}
+
+func TestPaginatorAllError(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		ints       = ints(30)
+		inducedErr = errors.New("something broke")
+	)
+
+	it := NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		switch start {
+		case 0:
+			// A partial page.
+			return ints[1:end], inducedErr
+		case 10:
+			return ints[start:end], nil
+		default:
+			return nil, inducedErr
+		}
+	})
+
+	var (
+		got  []int
+		errs int
+	)
+	for n, err := range it.All(ctx) {
+		if err != nil {
+			require.ErrorIs(t, err, inducedErr)
+			require.Zero(t, n)
+			errs++
+			continue
+		}
+		got = append(got, n)
+	}
+
+	// The partial page's error doesn't end the iteration, but the failure of
+	// the third page does.
+	require.Equal(t, ints[1:20], got)
+	require.Equal(t, 2, errs)
+}
+
+func TestPaginatorToken(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		ints = ints(25)
+	)
+
+	worker := func(ctx context.Context, start, end uint) ([]int, error) {
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+		return ints[start:min(end, uint(len(ints)))], nil
+	}
+
+	it, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, Name: "ints"}, worker)
+	require.NoError(t, err)
+	_, err = it.Next(ctx)
+	require.NoError(t, err)
+
+	resumed, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, Name: "ints", Token: it.Token()}, worker)
+	require.NoError(t, err)
+	ns, err := resumed.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[10:20], ns)
+
+	// A finished Paginator's token resumes at the end.
+	for range resumed.Pages(ctx) {
+	}
+	finished, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, Name: "ints", Token: resumed.Token()}, worker)
+	require.NoError(t, err)
+	_, err = finished.Next(ctx)
+	require.ErrorIs(t, err, EndOfIterator)
+
+	_, err = NewPaginatorWithOptions(ctx, Options{Limit: 10, Name: "other", Token: it.Token()}, worker)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	_, err = NewPaginatorWithOptions(ctx, Options{Limit: 5, Name: "ints", Token: it.Token()}, worker)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	_, err = NewPaginatorWithOptions(ctx, Options{Limit: 10, Name: "ints", Token: "not a token"}, worker)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPaginatorTokenOffsetMax(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		ints     = ints(100)
+		progress []Progress
+	)
+
+	worker := func(ctx context.Context, start, end uint) (Page[int], error) {
+		if start >= uint(len(ints)) {
+			return Page[int]{Results: []int{}, Total: len(ints)}, nil
+		}
+		return Page[int]{Results: ints[start:min(end, uint(len(ints)))], Total: len(ints)}, nil
+	}
+
+	it, err := NewPaginatorWithPages(ctx, Options{Limit: 10, Offset: 20, Max: 30, Name: "ints"}, worker)
+	require.NoError(t, err)
+	_, err = it.Next(ctx)
+	require.NoError(t, err)
+
+	// The token keeps the original Offset and Max, so the resumed Paginator
+	// numbers its pages and stops the same way.
+	resumed, err := NewPaginatorWithPages(ctx, Options{
+		Limit:    10,
+		Name:     "ints",
+		Token:    it.Token(),
+		Progress: func(p Progress) { progress = append(progress, p) },
+	}, worker)
+	require.NoError(t, err)
+	got, err := Collect(resumed.All(ctx))
+	require.NoError(t, err)
+	require.Equal(t, ints[30:50], got)
+	require.Equal(t, []Progress{
+		{Page: 2, Pages: 3, Offset: 40, Total: 100},
+		{Page: 3, Pages: 3, Offset: 50, Total: 100},
+	}, progress)
+
+	// Setting the same Offset and Max again is allowed, but a Max ending
+	// elsewhere isn't.
+	_, err = NewPaginatorWithPages(ctx, Options{Limit: 10, Offset: 20, Max: 30, Name: "ints", Token: it.Token()}, worker)
+	require.NoError(t, err)
+	_, err = NewPaginatorWithPages(ctx, Options{Limit: 10, Offset: 20, Max: 50, Name: "ints", Token: it.Token()}, worker)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPaginatorReadAhead(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		ints    = ints(55)
+		fetched = make(chan uint, 10)
+	)
+
+	it, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, ReadAhead: 2}, func(ctx context.Context, start, end uint) ([]int, error) {
+		fetched <- start
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+		return ints[start:min(end, uint(len(ints)))], nil
+	})
+	require.NoError(t, err)
+
+	ns, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[:10], ns)
+
+	// While the caller processes the first page, the next two are fetched in
+	// the background, plus one more waiting to be buffered.
+	for _, want := range []uint{0, 10, 20, 30} {
+		require.Equal(t, want, <-fetched)
+	}
+
+	var got []int
+	got = append(got, ns...)
+	for n, err := range it.All(ctx) {
+		require.NoError(t, err)
+		got = append(got, n)
+	}
+	require.Equal(t, ints, got)
+}
+
+func TestPaginatorReadAheadStop(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		inducedErr = errors.New("something broke")
+		failing    = true
+		fetched    []uint
+	)
+
+	it, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, ReadAhead: 1}, func(ctx context.Context, start, end uint) ([]int, error) {
+		fetched = append(fetched, start)
+		if start == 10 && failing {
+			return nil, inducedErr
+		}
+		return ints(int(end))[start:end], nil
+	})
+	require.NoError(t, err)
+
+	ns, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints(10), ns)
+
+	// A failed page stops reading ahead, and is retried by the next call.
+	_, err = it.Next(ctx)
+	require.ErrorIs(t, err, inducedErr)
+	failing = false
+	ns, err = it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 11, ns[0])
+
+	// Stopping discards the pages read ahead, and waits for the background
+	// fetches to finish.
+	it.Stop()
+	n := len(fetched)
+	ns, err = it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 21, ns[0])
+	it.Stop()
+	require.Equal(t, uint(20), fetched[n])
+}
+
+func TestPaginatorReadAheadContext(t *testing.T) {
+	ints := ints(30)
+
+	it, err := NewPaginatorWithOptions(context.Background(), Options{Limit: 10, ReadAhead: 1}, func(ctx context.Context, start, end uint) ([]int, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+		return ints[start:min(end, uint(len(ints)))], nil
+	})
+	require.NoError(t, err)
+	defer it.Stop()
+
+	// Each call to Next has its own context. Cancelling the first one once
+	// it has returned doesn't fail the pages read ahead for later calls.
+	ctx, cancel := context.WithCancel(context.Background())
+	ns, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[:10], ns)
+	cancel()
+
+	ns, err = it.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, ints[10:20], ns)
+
+	// A call whose context has already ended returns without a page.
+	_, err = it.Next(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	ns, err = it.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, ints[20:30], ns)
+}
+
+func TestPaginatorReadAheadCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, ReadAhead: 1}, func(ctx context.Context, start, end uint) ([]int, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return ints(int(end))[start:end], nil
+	})
+	require.NoError(t, err)
+
+	ns, err := it.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, ints(10), ns)
+
+	// Cancelling the context the Paginator was created with stops the
+	// background fetches without a call to Stop.
+	stopped := it.stopped
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("background fetches didn't stop")
+	}
+
+	_, err = it.Next(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPaginatorPages(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		ints     = ints(25)
+		progress []Progress
+	)
+
+	it, err := NewPaginatorWithPages(ctx, Options{Limit: 10, Progress: func(p Progress) {
+		progress = append(progress, p)
+	}}, func(ctx context.Context, start, end uint) (Page[int], error) {
+		if start >= uint(len(ints)) {
+			return Page[int]{Results: []int{}, Total: len(ints)}, nil
+		}
+		return Page[int]{Results: ints[start:min(end, uint(len(ints)))], Total: len(ints)}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, -1, it.Total())
+	require.True(t, it.HasNext())
+
+	for i := 0; i < 2; i++ {
+		_, err = it.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 25, it.Total())
+		require.True(t, it.HasNext())
+	}
+	_, err = it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint(25), it.Offset())
+	require.False(t, it.HasNext())
+
+	_, err = it.Next(ctx)
+	require.ErrorIs(t, err, EndOfIterator)
+	require.Equal(t, []Progress{
+		{Page: 1, Pages: 3, Offset: 10, Total: 25},
+		{Page: 2, Pages: 3, Offset: 20, Total: 25},
+		{Page: 3, Pages: 3, Offset: 25, Total: 25},
+	}, progress)
+
+	// A worker that doesn't report the total leaves it unknown.
+	plain := NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		return ints[:10], nil
+	})
+	_, err = plain.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, -1, plain.Total())
+	require.True(t, plain.HasNext())
+}
+
+func TestPaginatorOffset(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		ints = ints(300)
+		ends []uint
+	)
+
+	worker := func(ctx context.Context, start, end uint) ([]int, error) {
+		ends = append(ends, end)
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+		return ints[start:min(end, uint(len(ints)))], nil
+	}
+
+	it, err := NewPaginatorWithOptions(ctx, Options{Limit: 20, Offset: 151, Max: 100}, worker)
+	require.NoError(t, err)
+
+	var got []int
+	for n, err := range it.All(ctx) {
+		require.NoError(t, err)
+		got = append(got, n)
+	}
+	require.Equal(t, ints[151:251], got)
+	// The last page only requests up to the Max, and no page is requested
+	// after it.
+	require.Equal(t, []uint{171, 191, 211, 231, 251}, ends)
+	require.False(t, it.HasNext())
+
+	// Seeking restarts a finished iteration, still ending at the Max.
+	it.Seek(241)
+	ns, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[241:251], ns)
+	_, err = it.Next(ctx)
+	require.ErrorIs(t, err, EndOfIterator)
+
+	// Without a Max the iteration continues until the end of the collection.
+	it, err = NewPaginatorWithOptions(ctx, Options{Limit: 20, ReadAhead: 1}, worker)
+	require.NoError(t, err)
+	it.Seek(290)
+	ns, err = it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[290:], ns)
+	it.Seek(10)
+	ns, err = it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ints[10:30], ns)
+	it.Stop()
+}
+
+func TestSlice(t *testing.T) {
+	ctx := context.Background()
+
+	var it Iterator[int] = NewSlice(10, ints(25))
+	for _, want := range [][]int{ints(25)[:10], ints(25)[10:20], ints(25)[20:]} {
+		ns, err := it.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, ns)
+	}
+	_, err := it.Next(ctx)
+	require.ErrorIs(t, err, EndOfIterator)
+
+	// Without a page size all values are a single page.
+	var pages int
+	for page, err := range NewSlice(0, ints(5)).Pages(ctx) {
+		require.NoError(t, err)
+		require.Equal(t, ints(5), page)
+		pages++
+	}
+	require.Equal(t, 1, pages)
+
+	got, err := Collect(NewSlice(0, []int{}).All(ctx))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestConcat(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		inducedErr = errors.New("something broke")
+		failing    = true
+	)
+
+	paginator := NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		if failing {
+			failing = false
+			return nil, inducedErr
+		}
+		if start >= 20 {
+			return []int{}, nil
+		}
+		return ints(int(end))[start:end], nil
+	})
+	it := Concat[int](NewSlice(2, []int{-2, -1}), NewSlice(0, []int{}), paginator, NewSlice(0, []int{100}))
+
+	ns, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{-2, -1}, ns)
+
+	// An error doesn't move on to the next iterator, so the page is retried.
+	_, err = it.Next(ctx)
+	require.ErrorIs(t, err, inducedErr)
+
+	got, err := Collect(All(ctx, it))
+	require.NoError(t, err)
+	require.Equal(t, append(ints(20), 100), got)
+}
+
+func TestPaginatorOffsetProgress(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		ints     = ints(1302)
+		progress []Progress
+	)
+
+	it, err := NewPaginatorWithPages(ctx, Options{Limit: 20, Offset: 151, Max: 100, Progress: func(p Progress) {
+		progress = append(progress, p)
+	}}, func(ctx context.Context, start, end uint) (Page[int], error) {
+		return Page[int]{Results: ints[start:min(end, uint(len(ints)))], Total: len(ints)}, nil
+	})
+	require.NoError(t, err)
+	for _, err := range it.Pages(ctx) {
+		require.NoError(t, err)
+	}
+
+	// Pages are counted from the Offset to the Max, not across the whole
+	// collection.
+	require.Len(t, progress, 5)
+	for i, p := range progress {
+		require.Equal(t, i+1, p.Page)
+		require.Equal(t, 5, p.Pages)
+	}
+	require.Equal(t, uint(251), progress[4].Offset)
+
+	// After seeking, pages are counted from the new offset.
+	progress = nil
+	it.Seek(211)
+	for _, err := range it.Pages(ctx) {
+		require.NoError(t, err)
+	}
+	require.Equal(t, []Progress{
+		{Page: 1, Pages: 2, Offset: 231, Total: 1302},
+		{Page: 2, Pages: 2, Offset: 251, Total: 1302},
+	}, progress)
+}
+
+// pagesFunc is a minimal Iterator implemented outside of the package's types,
+// like a mock would be.
+type pagesFunc func(ctx context.Context) ([]int, error)
+
+func (f pagesFunc) Next(ctx context.Context) ([]int, error) {
+	return f(ctx)
+}
+
+func TestPagesAll(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		inducedErr = errors.New("something broke")
+		calls      int
+	)
+
+	it := pagesFunc(func(ctx context.Context) ([]int, error) {
+		calls++
+		switch calls {
+		case 1:
+			return []int{1, 2}, nil
+		case 2:
+			// A partial page.
+			return []int{3}, inducedErr
+		case 3:
+			return []int{4, 5}, nil
+		default:
+			return nil, EndOfIterator
+		}
+	})
+
+	var (
+		got  []int
+		errs int
+	)
+	for n, err := range All(ctx, it) {
+		if err != nil {
+			require.ErrorIs(t, err, inducedErr)
+			errs++
+			continue
+		}
+		got = append(got, n)
+		if n == 4 {
+			break
+		}
+	}
+	require.Equal(t, []int{1, 2, 3, 4}, got)
+	require.Equal(t, 1, errs)
+	require.Equal(t, 3, calls)
+
+	// A Paginator's own Pages is used, so read-ahead stops when the loop
+	// does.
+	p, err := NewPaginatorWithOptions(ctx, Options{Limit: 10, ReadAhead: 2}, func(ctx context.Context, start, end uint) ([]int, error) {
+		return ints(int(end))[start:end], nil
+	})
+	require.NoError(t, err)
+	for range Pages[int](ctx, p) {
+		break
+	}
+	require.Nil(t, p.stop)
+}
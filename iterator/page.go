@@ -0,0 +1,96 @@
+package iterator
+
+import "errors"
+
+// Page is a page of results returned by the worker of a Paginator created
+// with NewPaginatorWithPages.
+type Page[T any] struct {
+	Results []T
+	// Total is the number of results in the whole collection, or -1 if it
+	// isn't known.
+	Total int
+}
+
+// PartialError is implemented by errors returned alongside a page that was
+// fetched, although some of its results couldn't be, such as a
+// *pokedex.PageError for a list with PartialResults. If Partial returns true
+// the page is consumed even if it has no results, so iteration continues with
+// the next page rather than retrying it.
+type PartialError interface {
+	error
+	Partial() bool
+}
+
+// partial reports whether err was returned alongside a page that was
+// consumed, as described by PartialError.
+func partial(err error) bool {
+	var p PartialError
+	return errors.As(err, &p) && p.Partial()
+}
+
+// Progress is the position of a Paginator in its collection, reported to
+// Options.Progress after each page.
+type Progress struct {
+	// Page is the number of the page just returned, starting from 1 at
+	// Options.Offset, or the offset passed to Seek.
+	Page int
+	// Pages is the number of pages from the same offset to the end of the
+	// collection, or Options.Max, or 0 if it isn't known.
+	Pages int
+	// Offset is the offset of the next page.
+	Offset uint
+	// Total is the number of results in the collection, or -1 if it isn't
+	// known.
+	Total int
+}
+
+// Total returns the number of results in the whole collection, or -1 if it
+// isn't known. It's known once the first page has been fetched by a worker
+// that reports it.
+func (it *Paginator[T]) Total() int {
+	return it.total
+}
+
+// Offset returns the offset of the next page, which is the number of results
+// returned so far plus the offset the Paginator started or was resumed from.
+func (it *Paginator[T]) Offset() uint {
+	return it.offset
+}
+
+// HasNext reports whether there may be another page. It's false once the
+// iteration has finished, or the offset has reached Total or Options.Max.
+func (it *Paginator[T]) HasNext() bool {
+	if it.done {
+		return false
+	}
+	if it.end > 0 && it.offset >= it.end {
+		return false
+	}
+	return it.total < 0 || it.offset < uint(it.total)
+}
+
+// report calls the Progress callback, if any, after the page at start has
+// been returned.
+func (it *Paginator[T]) report(start uint) {
+	if it.progress == nil {
+		return
+	}
+	p := Progress{
+		Offset: it.offset,
+		Total:  it.total,
+	}
+	if it.limit > 0 {
+		// Pages are numbered from the offset the iteration started from,
+		// rather than the start of the collection.
+		p.Page = int(start-min(start, it.origin))/int(it.limit) + 1
+		if it.total >= 0 {
+			last := uint(it.total)
+			if it.end > 0 {
+				last = min(last, it.end)
+			}
+			remaining := last - min(last, it.origin)
+			p.Pages = int((remaining + it.limit - 1) / it.limit)
+		}
+	}
+	it.progress(p)
+}
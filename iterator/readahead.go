@@ -0,0 +1,86 @@
+package iterator
+
+import "context"
+
+// fetched is a page fetched in the background by a read-ahead Paginator.
+type fetched[T any] struct {
+	page Page[T]
+	err  error
+}
+
+// nextReadAhead returns the next page fetched in the background, starting
+// the background fetches if they aren't running. It waits for the page until
+// ctx or the Paginator's context ends, but only the Paginator's context
+// affects the background fetches.
+func (it *Paginator[T]) nextReadAhead(ctx context.Context) (Page[T], error) {
+	// A page already read ahead isn't handed to a caller that has given up
+	// on it, or once the Paginator's context has ended.
+	if err := ctx.Err(); err != nil {
+		return Page[T]{Total: -1}, err
+	}
+	if err := it.ctx.Err(); err != nil {
+		return Page[T]{Total: -1}, err
+	}
+	if it.pages == nil {
+		it.startReadAhead()
+	}
+
+	select {
+	case f := <-it.pages:
+		return f.page, f.err
+	case <-ctx.Done():
+		return Page[T]{Total: -1}, ctx.Err()
+	case <-it.ctx.Done():
+		return Page[T]{Total: -1}, it.ctx.Err()
+	}
+}
+
+// startReadAhead starts fetching pages from the Paginator's offset in the
+// background, up to readAhead pages ahead of the caller. The fetches use the
+// Paginator's context rather than the one passed to Next, since each call to
+// Next may use a different context, so they run until it ends or Stop is
+// called.
+func (it *Paginator[T]) startReadAhead() {
+	ctx, cancel := context.WithCancel(it.ctx)
+	var (
+		pages   = make(chan fetched[T], it.readAhead)
+		stopped = make(chan struct{})
+	)
+	it.pages, it.stop, it.stopped = pages, cancel, stopped
+
+	start := it.offset
+	go func() {
+		defer close(stopped)
+
+		for {
+			p, err := it.fetch(ctx, start)
+			select {
+			case pages <- fetched[T]{page: p, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			// Stop after the last page, or a failed page that the caller
+			// will retry.
+			if len(p.Results) == 0 && !partial(err) {
+				return
+			}
+			start = it.following(start, p.Results, err)
+		}
+	}()
+}
+
+// Stop cancels any pages being fetched in the background by a Paginator with
+// Options.ReadAhead, and discards any that haven't been returned yet. Calling
+// Next again resumes from the page after the last one returned. Stop is only
+// needed if the caller stops calling Next before the end and the context the
+// Paginator was created with isn't cancelled; it's called automatically at
+// the end of the iteration, when Next fails, and when ranging over All or
+// Pages ends.
+func (it *Paginator[T]) Stop() {
+	if it.stop == nil {
+		return
+	}
+	it.stop()
+	<-it.stopped
+	it.pages, it.stop, it.stopped = nil, nil, nil
+}
@@ -0,0 +1,156 @@
+package iterator
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// The helpers below compose with the iterators returned by Paginator.All, for
+// example:
+//
+//	fire := iterator.Filter(it.All(ctx), func(p *models.Pokemon) bool {
+//		return hasType(p, "fire")
+//	})
+//	first, err := iterator.Collect(iterator.Take(fire, 10))
+//
+// They're lazy, so pages are only fetched as the results are needed. Errors
+// are passed through unchanged, with the zero value of T.
+
+// Filter returns an iterator over the results of seq for which keep returns
+// true.
+func Filter[T any](seq iter.Seq2[T, error], keep func(T) bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range seq {
+			if err == nil && !keep(v) {
+				continue
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns an iterator over the results of seq transformed by f.
+func Map[T, U any](seq iter.Seq2[T, error], f func(T) U) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		for v, err := range seq {
+			if err != nil {
+				var zero U
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if !yield(f(v), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns an iterator over the first n results of seq. Once n results
+// have been yielded seq is stopped, so no further pages are fetched.
+func Take[T any](seq iter.Seq2[T, error], n int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v, err := range seq {
+			if !yield(v, err) {
+				return
+			}
+			if err != nil {
+				continue
+			}
+			taken++
+			if taken == n {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns the results of seq as a slice. If seq yields an error, the
+// results so far are returned alongside it.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var results []T
+	for v, err := range seq {
+		if err != nil {
+			return results, err
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// Count returns the number of results of seq. If seq yields an error, the
+// count so far is returned alongside it.
+func Count[T any](seq iter.Seq2[T, error]) (int, error) {
+	n := 0
+	for _, err := range seq {
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// ForEach calls f for each result of seq, with up to concurrency calls
+// running at once. The first error, from seq or f, stops the iteration,
+// cancels the context passed to any running calls and is returned once they
+// have finished.
+func ForEach[T any](ctx context.Context, seq iter.Seq2[T, error], concurrency int, f func(ctx context.Context, v T) error) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	for v, err := range seq {
+		if err != nil {
+			fail(err)
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(v T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := f(ctx, v); err != nil {
+				fail(err)
+			}
+		}(v)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,104 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingPaginator returns a Paginator over n integers in pages of 10, which
+// counts the pages fetched.
+func countingPaginator(ctx context.Context, n int, pages *atomic.Int64) *Paginator[int] {
+	ints := ints(n)
+	return NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		pages.Add(1)
+		if start >= uint(len(ints)) {
+			return []int{}, nil
+		}
+		return ints[start:min(end, uint(len(ints)))], nil
+	})
+}
+
+func TestSeq(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		pages atomic.Int64
+	)
+
+	it := countingPaginator(ctx, 100, &pages)
+	even := Filter(it.All(ctx), func(n int) bool { return n%2 == 0 })
+	squares := Map(even, func(n int) int { return n * n })
+	got, err := Collect(Take(squares, 7))
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 16, 36, 64, 100, 144, 196}, got)
+	// Only the pages holding the first 7 even numbers were fetched.
+	require.Equal(t, int64(2), pages.Load())
+
+	pages.Store(0)
+	n, err := Count(countingPaginator(ctx, 55, &pages).All(ctx))
+	require.NoError(t, err)
+	require.Equal(t, 55, n)
+	require.Equal(t, int64(7), pages.Load())
+
+	got, err = Collect(Take(countingPaginator(ctx, 5, &pages).All(ctx), 0))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestSeqError(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		inducedErr = errors.New("something broke")
+	)
+
+	it := NewPaginator(ctx, 10, func(ctx context.Context, start, end uint) ([]int, error) {
+		if start >= 20 {
+			return nil, inducedErr
+		}
+		return ints(int(end))[start:end], nil
+	})
+	doubled := Map(Filter(it.All(ctx), func(n int) bool { return n > 5 }), func(n int) int { return n * 2 })
+	got, err := Collect(doubled)
+	require.ErrorIs(t, err, inducedErr)
+	require.Len(t, got, 15)
+	require.Equal(t, 12, got[0])
+}
+
+func TestForEach(t *testing.T) {
+	var (
+		ctx                    = context.Background()
+		pages                  atomic.Int64
+		sum, inFlight, maxSeen atomic.Int64
+	)
+
+	err := ForEach(ctx, countingPaginator(ctx, 100, &pages).All(ctx), 4, func(ctx context.Context, n int) error {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxSeen.Load()
+			if cur <= m || maxSeen.CompareAndSwap(m, cur) {
+				break
+			}
+		}
+		sum.Add(int64(n))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5050), sum.Load())
+	require.LessOrEqual(t, maxSeen.Load(), int64(4))
+
+	// The first error stops the iteration.
+	inducedErr := errors.New("something broke")
+	pages.Store(0)
+	err = ForEach(ctx, countingPaginator(ctx, 100, &pages).All(ctx), 2, func(ctx context.Context, n int) error {
+		if n == 3 {
+			return inducedErr
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, inducedErr)
+	require.Less(t, pages.Load(), int64(10))
+}
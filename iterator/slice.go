@@ -0,0 +1,49 @@
+package iterator
+
+import (
+	"context"
+	"iter"
+)
+
+// Slice is an Iterator over values held in memory, split into pages. It's
+// useful as a stand-in for a Paginator in tests. Like a Paginator, it is not
+// safe to use by multiple goroutines simultaneously.
+type Slice[T any] struct {
+	values []T
+	limit  uint
+	offset uint
+}
+
+// NewSlice returns a Slice over values with pages of size limit. If limit is
+// zero, all the values are returned as a single page.
+func NewSlice[T any](limit uint, values []T) *Slice[T] {
+	if limit == 0 {
+		limit = uint(max(len(values), 1))
+	}
+	return &Slice[T]{values: values, limit: limit}
+}
+
+func (it *Slice[T]) Next(ctx context.Context) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if it.offset >= uint(len(it.values)) {
+		return nil, EndOfIterator
+	}
+
+	start := it.offset
+	it.offset = min(start+it.limit, uint(len(it.values)))
+	return it.values[start:it.offset], nil
+}
+
+// Pages returns an iterator over the remaining pages, as described by
+// Paginator.Pages.
+func (it *Slice[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return pages(ctx, it.Next)
+}
+
+// All returns an iterator over each remaining value, as described by
+// Paginator.All.
+func (it *Slice[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return results(it.Pages(ctx))
+}
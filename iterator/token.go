@@ -0,0 +1,64 @@
+package iterator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidToken is returned when a token can't be used to resume a
+// Paginator, because it's malformed or was created by a Paginator over a
+// different collection or with a different page size.
+var ErrInvalidToken = errors.New("invalid page token")
+
+// tokenVersion is incremented whenever the contents of a token change, so old
+// tokens are rejected rather than misread.
+const tokenVersion = 2
+
+// cursor is the position of a Paginator, encoded in its tokens.
+type cursor struct {
+	Version int    `json:"v"`
+	Name    string `json:"n"`
+	Limit   uint   `json:"l"`
+	Offset  uint   `json:"o"`
+	// Origin and End are the Paginator's start offset, from Options.Offset or
+	// Seek, and the offset Options.Max stops it at, so a resumed Paginator
+	// reports the same Progress and stops at the same place.
+	Origin uint `json:"s,omitempty"`
+	End    uint `json:"e,omitempty"`
+	Done   bool `json:"d,omitempty"`
+}
+
+// Token returns an opaque token recording the Paginator's position. It can be
+// stored, for example alongside the results of a long crawl, and passed to
+// NewPaginatorWithOptions to resume from the next page if the crawl is
+// interrupted.
+func (it *Paginator[T]) Token() string {
+	b, _ := json.Marshal(cursor{
+		Version: tokenVersion,
+		Name:    it.name,
+		Limit:   it.limit,
+		Offset:  it.offset,
+		Origin:  it.origin,
+		End:     it.end,
+		Done:    it.done,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func parseToken(token string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	var c cursor
+	err = json.Unmarshal(b, &c)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if c.Version != tokenVersion {
+		return cursor{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidToken, c.Version)
+	}
+	return c, nil
+}
@@ -0,0 +1,15 @@
+package pokedex
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler is a slog.Handler that drops every record. It's used when no
+// logger is provided, so the SDK client is silent by default.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,87 @@
+package pokedex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stat":
+			fmt.Fprint(w, `{"count": 1, "next": null, "previous": null, "results": [{"name": "hp", "url": ""}]}`)
+		default:
+			fmt.Fprint(w, `{"id": 1, "name": "hp"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var logs bytes.Buffer
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		Logger: slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		})),
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	_, err = sdk.GetStat(ctx, GetRequest{Name: "hp"})
+	require.NoError(t, err)
+
+	res, err := sdk.ListStats(ctx, ListRequest{PageSize: 1})
+	require.NoError(t, err)
+	_, err = res.Iterator.Next(ctx)
+	require.NoError(t, err)
+
+	var events []map[string]any
+	dec := json.NewDecoder(&logs)
+	for dec.More() {
+		var event map[string]any
+		require.NoError(t, dec.Decode(&event))
+		events = append(events, event)
+	}
+
+	var messages []string
+	for _, event := range events {
+		messages = append(messages, event["msg"].(string))
+	}
+	require.Equal(t, []string{
+		"cache miss",
+		"request started",
+		"request finished",
+		"cache set",
+		"list page started",
+		"cache miss",
+		"request started",
+		"request finished",
+		// The Stat has already been fetched, so it's read from the cache and
+		// isn't written again.
+		"cache hit",
+		"list page finished",
+	}, messages)
+
+	finished := events[2]
+	require.Equal(t, srv.URL+"/stat/hp", finished["url"])
+	require.Equal(t, float64(http.StatusOK), finished["status"])
+	require.Equal(t, float64(len(`{"id": 1, "name": "hp"}`)), finished["bytes"])
+	require.Contains(t, finished, "duration")
+
+	page := events[9]
+	require.Equal(t, "stat", page["resource"])
+	require.Equal(t, float64(1), page["results"])
+}
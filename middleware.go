@@ -0,0 +1,78 @@
+package pokedex
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Middleware wraps an http.RoundTripper to add behaviour to every request made
+// by the SDK client, for example request signing, proxying or logging.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as
+// an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// chain wraps rt with each middleware. The first middleware is the outermost,
+// so it's the first to see a request and the last to see its response.
+func chain(rt http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		rt = middleware[i](rt)
+	}
+	return rt
+}
+
+// UserAgent sets the User-Agent header on every request.
+func UserAgent(userAgent string) Middleware {
+	return Headers(http.Header{"User-Agent": []string{userAgent}})
+}
+
+// Headers sets the provided headers on every request, replacing any existing
+// values for the same keys.
+func Headers(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			// An http.RoundTripper must not modify the request it's given, so
+			// the headers are set on a copy.
+			r = r.Clone(r.Context())
+			for k, v := range headers {
+				// The values are copied, so anything downstream modifying the
+				// request's headers doesn't modify the middleware's.
+				r.Header[http.CanonicalHeaderKey(k)] = slices.Clone(v)
+			}
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// Logging logs the method, URL, status and duration of every request.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.RoundTrip(r)
+			if err != nil {
+				logger.ErrorContext(r.Context(), "http request failed",
+					slog.String("method", r.Method),
+					slog.String("url", r.URL.String()),
+					slog.Duration("duration", time.Since(start)),
+					slog.Any("error", err),
+				)
+				return nil, err
+			}
+			logger.InfoContext(r.Context(), "http request",
+				slog.String("method", r.Method),
+				slog.String("url", r.URL.String()),
+				slog.Int("status", res.StatusCode),
+				slog.Duration("duration", time.Since(start)),
+			)
+			return res, nil
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package pokedex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		fmt.Fprint(w, `{"id": 1, "name": "hp"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	// Each middleware appends to the X-Order header, so we can assert the
+	// order they're applied in.
+	order := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				r = r.Clone(r.Context())
+				if v := r.Header.Get("X-Order"); v != "" {
+					name = v + "," + name
+				}
+				r.Header.Set("X-Order", name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+
+	var logs bytes.Buffer
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		Middleware: []Middleware{
+			UserAgent("pokedex-test"),
+			Headers(http.Header{"X-Api-Key": []string{"secret"}}),
+			order("first"),
+			order("second"),
+			Logging(slog.New(slog.NewTextHandler(&logs, nil))),
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.GetStat(ctx, GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "hp", res.Stat.Name)
+
+	require.Equal(t, "pokedex-test", header.Get("User-Agent"))
+	require.Equal(t, "secret", header.Get("X-Api-Key"))
+	require.Equal(t, "first,second", header.Get("X-Order"))
+
+	require.Contains(t, logs.String(), "url="+srv.URL+"/stat/1")
+	require.Contains(t, logs.String(), "status=200")
+}
+
+func TestTransport(t *testing.T) {
+	ctx := context.Background()
+
+	var requests []string
+	transport := RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests = append(requests, r.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"id": 1, "name": "hp"}`)),
+			Request:    r,
+		}, nil
+	})
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          "https://example.com",
+		HTTPClient:       &http.Client{Timeout: time.Second},
+		Transport:        transport,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.GetStat(ctx, GetRequest{Name: "hp"})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Stat.ID)
+	require.Equal(t, []string{"https://example.com/stat/hp"}, requests)
+}
+
+func TestHeaders_Copied(t *testing.T) {
+	headers := http.Header{"X-Api-Key": []string{"secret"}}
+
+	// A downstream middleware edits the header it's given in place.
+	var seen []string
+	rt := Headers(headers)(RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		r.Header["X-Api-Key"][0] = "changed"
+		r.Header["X-Api-Key"] = append(r.Header["X-Api-Key"], "appended")
+		seen = r.Header["X-Api-Key"]
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	}))
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		require.NoError(t, err)
+		_, err = rt.RoundTrip(req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"changed", "appended"}, seen)
+	}
+	require.Equal(t, []string{"secret"}, headers["X-Api-Key"])
+}
@@ -0,0 +1,324 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The models are generated from the JSON schemas in api/ by cmd/pokedex-gen,
+// so these tests check the generator's output agrees with them. For each
+// schema, random documents valid against it are unmarshalled into the
+// corresponding model and marshalled again. The result must keep every field
+// of the original document and still be valid against the schema. The model
+// of each schema is registered in schemaModels, which is also generated.
+
+// schemaIterations is the number of random documents checked for each schema.
+const schemaIterations = 200
+
+func TestSchemaConformance(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "api", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	loader := &schemaLoader{dir: filepath.Join("..", "api"), schemas: make(map[string]map[string]any)}
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			newModel, ok := schemaModels[name]
+			require.True(t, ok, "no model registered for schema %s", name)
+
+			schema, err := loader.load(name)
+			require.NoError(t, err)
+
+			for seed := int64(1); seed <= schemaIterations; seed++ {
+				g := &schemaGenerator{loader: loader, rand: rand.New(rand.NewSource(seed))}
+				doc, err := json.Marshal(g.generate(schema, 0))
+				require.NoError(t, err)
+
+				model := newModel()
+				require.NoError(t, json.Unmarshal(doc, model), "seed %d: %s", seed, doc)
+				serialised, err := json.Marshal(model)
+				require.NoError(t, err)
+
+				var want, got any
+				require.NoError(t, json.Unmarshal(doc, &want))
+				require.NoError(t, json.Unmarshal(serialised, &got))
+
+				if diffs := missing(want, got, "$"); len(diffs) > 0 {
+					t.Fatalf("seed %d: model drops fields of the schema:\n%s", seed, strings.Join(diffs, "\n"))
+				}
+				if errs := loader.validate(schema, got, "$"); len(errs) > 0 {
+					t.Fatalf("seed %d: model output doesn't match the schema:\n%s", seed, strings.Join(errs, "\n"))
+				}
+			}
+		})
+	}
+}
+
+// schemaLoader loads the JSON schemas in dir, resolving "$ref"s to other files
+// in the same directory.
+type schemaLoader struct {
+	dir     string
+	schemas map[string]map[string]any
+}
+
+func (l *schemaLoader) load(name string) (map[string]any, error) {
+	if s, ok := l.schemas[name]; ok {
+		return s, nil
+	}
+	b, err := os.ReadFile(filepath.Join(l.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	var s map[string]any
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	l.schemas[name] = s
+	return s, nil
+}
+
+// resolve follows schema's "$ref", if it has one.
+func (l *schemaLoader) resolve(schema map[string]any) map[string]any {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	s, err := l.load(ref)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// types returns the JSON types allowed by schema's "type" keyword.
+func types(schema map[string]any) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		ts := make([]string, len(t))
+		for i, v := range t {
+			ts[i] = v.(string)
+		}
+		return ts
+	}
+	return nil
+}
+
+// validate checks v against the subset of JSON schema used in api/: "type",
+// "properties", "required", "items", "anyOf" and "$ref". It returns a
+// description of each violation.
+func (l *schemaLoader) validate(schema map[string]any, v any, path string) []string {
+	schema = l.resolve(schema)
+
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		for _, sub := range anyOf {
+			if len(l.validate(sub.(map[string]any), v, path)) == 0 {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: %s matches none of anyOf", path, describe(v))}
+	}
+
+	ts := types(schema)
+	if len(ts) == 0 {
+		return nil
+	}
+	var matched string
+	for _, t := range ts {
+		if hasType(v, t) {
+			matched = t
+			break
+		}
+	}
+	if matched == "" {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, strings.Join(ts, " or "), describe(v))}
+	}
+
+	var errs []string
+	switch matched {
+	case "object":
+		obj := v.(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, r := range required {
+				if _, ok := obj[r.(string)]; !ok {
+					errs = append(errs, fmt.Sprintf("%s: missing required field %q", path, r))
+				}
+			}
+		}
+		if properties, ok := schema["properties"].(map[string]any); ok {
+			for _, name := range sortedKeys(properties) {
+				if value, ok := obj[name]; ok {
+					errs = append(errs, l.validate(properties[name].(map[string]any), value, path+"."+name)...)
+				}
+			}
+		}
+	case "array":
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v.([]any) {
+				errs = append(errs, l.validate(items, item, path+"["+strconv.Itoa(i)+"]")...)
+			}
+		}
+	}
+	return errs
+}
+
+func hasType(v any, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+func describe(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// missing returns a description of each value in want that isn't in got. got
+// may have extra fields, as models marshal optional fields that weren't set as
+// null.
+func missing(want, got any, path string) []string {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object, got %s", path, describe(got))}
+		}
+		var diffs []string
+		for _, k := range sortedKeys(w) {
+			if _, ok := g[k]; !ok {
+				diffs = append(diffs, fmt.Sprintf("%s.%s: missing", path, k))
+				continue
+			}
+			diffs = append(diffs, missing(w[k], g[k], path+"."+k)...)
+		}
+		return diffs
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, describe(want), describe(got))}
+		}
+		var diffs []string
+		for i := range w {
+			diffs = append(diffs, missing(w[i], g[i], path+"["+strconv.Itoa(i)+"]")...)
+		}
+		return diffs
+	}
+	if !reflect.DeepEqual(want, got) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, describe(want), describe(got))}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// maximumDepth stops the generator recursing forever through "$ref"s. Past it,
+// optional fields and array items are no longer generated.
+const maximumDepth = 12
+
+// schemaGenerator generates random documents valid against a schema.
+type schemaGenerator struct {
+	loader *schemaLoader
+	rand   *rand.Rand
+}
+
+func (g *schemaGenerator) generate(schema map[string]any, depth int) any {
+	schema = g.loader.resolve(schema)
+
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		return g.generate(anyOf[g.rand.Intn(len(anyOf))].(map[string]any), depth)
+	}
+
+	ts := types(schema)
+	if len(ts) == 0 {
+		return nil
+	}
+	switch ts[g.rand.Intn(len(ts))] {
+	case "boolean":
+		return g.rand.Intn(2) == 0
+	case "string":
+		return g.string()
+	case "number":
+		return g.rand.NormFloat64() * 100
+	case "integer":
+		return g.rand.Intn(2000) - 1000
+	case "array":
+		items, ok := schema["items"].(map[string]any)
+		if !ok || depth >= maximumDepth {
+			return []any{}
+		}
+		arr := make([]any, g.rand.Intn(4))
+		for i := range arr {
+			arr[i] = g.generate(items, depth+1)
+		}
+		return arr
+	case "object":
+		obj := make(map[string]any)
+		properties, _ := schema["properties"].(map[string]any)
+		required := make(map[string]bool)
+		if rs, ok := schema["required"].([]any); ok {
+			for _, r := range rs {
+				required[r.(string)] = true
+			}
+		}
+		for _, name := range sortedKeys(properties) {
+			if !required[name] && (depth >= maximumDepth || g.rand.Intn(2) == 0) {
+				continue
+			}
+			obj[name] = g.generate(properties[name].(map[string]any), depth+1)
+		}
+		return obj
+	}
+	return nil
+}
+
+// string generates a random string, including characters that need escaping
+// in JSON.
+func (g *schemaGenerator) string() string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz-_ é\"\\/"
+	runes := []rune(alphabet)
+	s := make([]rune, g.rand.Intn(16))
+	for i := range s {
+		s[i] = runes[g.rand.Intn(len(runes))]
+	}
+	return string(s)
+}
@@ -0,0 +1,14 @@
+// Code generated by cmd/pokedex-gen. DO NOT EDIT.
+
+package models
+
+// schemaModels maps each schema in api/ to the model generated from it, for
+// the schema conformance tests.
+var schemaModels = map[string]func() any{
+	"api_resource.json":            func() any { return new(ApiResource) },
+	"named_api_resource.json":      func() any { return new(NamedApiResource) },
+	"named_api_resource_list.json": func() any { return new(NamedApiResourceList) },
+	"nature.json":                  func() any { return new(Nature) },
+	"pokemon.json":                 func() any { return new(Pokemon) },
+	"stat.json":                    func() any { return new(Stat) },
+}
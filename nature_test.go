@@ -9,7 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/mdcurran/pokedex/internal/faker"
+	"github.com/mdcurran/pokedex/faker"
 	"github.com/stretchr/testify/require"
 )
 
@@ -67,6 +67,10 @@ func TestNature_NotFound(t *testing.T) {
 
 	require.Equal(t, "Not Found", sdkErr.Message)
 	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode)
+	require.Equal(t, KindNotFound, sdkErr.Kind)
+	require.Equal(t, srv.URL+"/nature/999999", sdkErr.URL)
+	require.Equal(t, "Not Found", sdkErr.Body)
+	require.ErrorIs(t, err, KindNotFound)
 }
 
 func TestNature_UnexpectedError(t *testing.T) {
@@ -95,6 +99,7 @@ func TestNature_UnexpectedError(t *testing.T) {
 
 	require.Equal(t, "Unexpected Error", sdkErr.Message)
 	require.Equal(t, http.StatusBadGateway, sdkErr.StatusCode)
+	require.Equal(t, KindUnexpectedStatus, sdkErr.Kind)
 }
 
 func TestNature_ClientClosed(t *testing.T) {
@@ -118,5 +123,66 @@ func TestNature_ClientClosed(t *testing.T) {
 	require.True(t, ok)
 
 	require.Equal(t, "sdk client closed", sdkErr.Message)
-	require.Equal(t, CodeClientClosed, sdkErr.StatusCode)
+	require.Equal(t, KindClosed, sdkErr.Kind)
+	require.ErrorIs(t, err, ErrClientClosed)
+}
+
+func TestListNatures_PageError(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nature":
+			fmt.Fprint(w, `{"count": 3, "next": null, "previous": null, "results": [
+				{"name": "hardy", "url": ""},
+				{"name": "bold", "url": ""},
+				{"name": "modest", "url": ""}
+			]}`)
+		case "/nature/hardy", "/nature/modest":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "Not Found")
+		default:
+			fmt.Fprint(w, `{"id": 2, "name": "bold"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.ListNatures(ctx, ListRequest{PageSize: 3})
+	require.NoError(t, err)
+
+	natures, err := res.Iterator.Next(ctx)
+	require.Empty(t, natures)
+
+	var pageErr *PageError
+	require.ErrorAs(t, err, &pageErr)
+	require.Equal(t, "nature", pageErr.Resource)
+	require.Len(t, pageErr.Errors, 2)
+	require.Equal(t, "hardy", pageErr.Errors[0].Name)
+	require.Equal(t, http.StatusNotFound, pageErr.Errors[0].Err.StatusCode)
+	require.Equal(t, "modest", pageErr.Errors[1].Name)
+
+	var sdkErr *SDKError
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode)
+	require.ErrorIs(t, err, KindNotFound)
+
+	// With partial results enabled the page includes the Nature that was
+	// fetched successfully.
+	res, err = sdk.ListNatures(ctx, ListRequest{PageSize: 3, PartialResults: true})
+	require.NoError(t, err)
+
+	natures, err = res.Iterator.Next(ctx)
+	require.ErrorAs(t, err, &pageErr)
+	require.Len(t, pageErr.Errors, 2)
+	require.Len(t, natures, 1)
+	require.Equal(t, "bold", natures[0].Name)
 }
@@ -0,0 +1,220 @@
+package pokedex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mdcurran/pokedex/internal/defaults"
+	"github.com/mdcurran/pokedex/models"
+)
+
+// offlineTransport is an http.RoundTripper that serves PokéAPI responses from
+// a local directory instead of the network. The directory is laid out like
+// the PokeAPI/api-data repository, so a resource is read from a path such as
+// api/v2/pokemon/1/index.json.
+//
+// Named resource lists are read from api/v2/<resource>/index.json if present,
+// otherwise they're built from the resource directories. Either way they're
+// paginated using the offset and limit query parameters, like the PokéAPI.
+type offlineTransport struct {
+	// root is the directory holding each resource, i.e. <dir>/api/v2.
+	root string
+	// basePath is the path of the SDK client's base URL, which is removed
+	// from each request path to find the resource.
+	basePath string
+}
+
+func newOfflineTransport(dir, basePath string) *offlineTransport {
+	return &offlineTransport{
+		root:     filepath.Join(dir, "api", "v2"),
+		basePath: strings.TrimSuffix(basePath, "/"),
+	}
+}
+
+func (t *offlineTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet {
+		return offlineResponse(r, http.StatusMethodNotAllowed, nil), nil
+	}
+
+	rel := strings.Trim(strings.TrimPrefix(r.URL.Path, t.basePath), "/")
+	segments := strings.Split(rel, "/")
+
+	var (
+		body []byte
+		err  error
+	)
+	switch {
+	case len(segments) == 1 && segments[0] != "":
+		body, err = t.list(r, segments[0])
+	case len(segments) == 2:
+		body, err = t.resource(segments[0], segments[1])
+	default:
+		err = fs.ErrNotExist
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return offlineResponse(r, http.StatusNotFound, []byte("Not Found")), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return offlineResponse(r, http.StatusOK, body), nil
+}
+
+// resource reads a single resource by its ID or name.
+func (t *offlineTransport) resource(resource, idOrName string) ([]byte, error) {
+	if _, err := strconv.Atoi(idOrName); err != nil {
+		id, err := t.resolveName(resource, idOrName)
+		if err != nil {
+			return nil, err
+		}
+		idOrName = id
+	}
+	return os.ReadFile(filepath.Join(t.root, resource, idOrName, "index.json"))
+}
+
+// resolveName finds the ID of a resource from its name, using the resource's
+// named resource list.
+func (t *offlineTransport) resolveName(resource, name string) (string, error) {
+	list, err := t.readList(resource)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range list.Results {
+		if item.Name == name {
+			return lastSegment(item.Url), nil
+		}
+	}
+	return "", fs.ErrNotExist
+}
+
+// list reads the page of a resource's named resource list requested by the
+// offset and limit query parameters.
+func (t *offlineTransport) list(r *http.Request, resource string) ([]byte, error) {
+	list, err := t.readList(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaults.PageSize
+	}
+
+	page := models.NamedApiResourceList{
+		Count:   list.Count,
+		Results: []models.NamedApiResource{},
+	}
+	if offset < len(list.Results) {
+		page.Results = list.Results[offset:min(offset+limit, len(list.Results))]
+	}
+	if offset+limit < len(list.Results) {
+		next := pageURL(r, offset+limit, limit)
+		page.Next = &next
+	}
+	if offset > 0 {
+		previous := pageURL(r, max(offset-limit, 0), limit)
+		page.Previous = &previous
+	}
+
+	return json.Marshal(page)
+}
+
+// readList reads the complete named resource list of a resource.
+func (t *offlineTransport) readList(resource string) (*models.NamedApiResourceList, error) {
+	b, err := os.ReadFile(filepath.Join(t.root, resource, "index.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return t.scanList(resource)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list *models.NamedApiResourceList
+	err = json.Unmarshal(b, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// scanList builds a named resource list from the directory of each resource,
+// ordered by ID.
+func (t *offlineTransport) scanList(resource string) (*models.NamedApiResourceList, error) {
+	entries, err := os.ReadDir(filepath.Join(t.root, resource))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, entry := range entries {
+		id, err := strconv.Atoi(entry.Name())
+		if entry.IsDir() && err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	list := &models.NamedApiResourceList{Count: len(ids)}
+	for _, id := range ids {
+		b, err := os.ReadFile(filepath.Join(t.root, resource, strconv.Itoa(id), "index.json"))
+		if err != nil {
+			return nil, err
+		}
+		var named models.NamedApiResource
+		err = json.Unmarshal(b, &named)
+		if err != nil {
+			return nil, err
+		}
+		list.Results = append(list.Results, models.NamedApiResource{
+			Name: named.Name,
+			Url:  "/" + path.Join("api", "v2", resource, strconv.Itoa(id)) + "/",
+		})
+	}
+	return list, nil
+}
+
+// pageURL returns the URL of the request with its offset and limit replaced.
+func pageURL(r *http.Request, offset, limit int) string {
+	u := *r.URL
+	q := u.Query()
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// lastSegment returns the final segment of a resource URL, such as the ID in
+// /api/v2/pokemon/1/.
+func lastSegment(u string) string {
+	return path.Base(strings.TrimSuffix(u, "/"))
+}
+
+func offlineResponse(r *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
@@ -0,0 +1,90 @@
+package pokedex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes body to path, creating any parent directories.
+func writeFile(t *testing.T, path, body string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+}
+
+func TestOffline(t *testing.T) {
+	ctx := context.Background()
+
+	// Natures have a named resource list, like PokeAPI/api-data. Stats
+	// don't, so their list is built from the directory.
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "api/v2/nature/index.json"), `{
+		"count": 3, "next": null, "previous": null, "results": [
+			{"name": "hardy", "url": "/api/v2/nature/1/"},
+			{"name": "bold", "url": "/api/v2/nature/2/"},
+			{"name": "modest", "url": "/api/v2/nature/3/"}
+		]
+	}`)
+	writeFile(t, filepath.Join(dir, "api/v2/nature/1/index.json"), `{"id": 1, "name": "hardy"}`)
+	writeFile(t, filepath.Join(dir, "api/v2/nature/2/index.json"), `{"id": 2, "name": "bold"}`)
+	writeFile(t, filepath.Join(dir, "api/v2/nature/3/index.json"), `{"id": 3, "name": "modest"}`)
+	writeFile(t, filepath.Join(dir, "api/v2/stat/2/index.json"), `{"id": 2, "name": "attack"}`)
+	writeFile(t, filepath.Join(dir, "api/v2/stat/10/index.json"), `{"id": 10, "name": "evasion"}`)
+	writeFile(t, filepath.Join(dir, "api/v2/stat/1/index.json"), `{"id": 1, "name": "hp"}`)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          defaultBaseURL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		OfflineDir:       dir,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.GetNature(ctx, GetRequest{ID: 2})
+	require.NoError(t, err)
+	require.Equal(t, "bold", res.Nature.Name)
+
+	res, err = sdk.GetNature(ctx, GetRequest{Name: "modest"})
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Nature.ID)
+
+	_, err = sdk.GetNature(ctx, GetRequest{Name: "missing"})
+	require.ErrorIs(t, err, KindNotFound)
+	_, err = sdk.GetPokemon(ctx, GetRequest{ID: 1})
+	require.ErrorIs(t, err, KindNotFound)
+
+	natures, err := sdk.ListNatures(ctx, ListRequest{PageSize: 2})
+	require.NoError(t, err)
+	page, err := natures.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "hardy", page[0].Name)
+	require.Equal(t, "bold", page[1].Name)
+	page, err = natures.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, "modest", page[0].Name)
+	_, err = natures.Iterator.Next(ctx)
+	require.ErrorIs(t, err, iterator.EndOfIterator)
+
+	stats, err := sdk.ListStats(ctx, ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	page2, err := stats.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, page2, 3)
+	require.Equal(t, "hp", page2[0].Name)
+	require.Equal(t, "attack", page2[1].Name)
+	require.Equal(t, "evasion", page2[2].Name)
+
+	list, err := sdk.fetchResourceList(ctx, "nature", 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, list.Count)
+	require.Equal(t, defaultBaseURL+"/nature?limit=1&offset=2", *list.Next)
+	require.Equal(t, defaultBaseURL+"/nature?limit=1&offset=0", *list.Previous)
+}
@@ -0,0 +1,321 @@
+// Package pokedextest provides an in-process fake PokéAPI server, for testing
+// code built on the SDK without the network.
+//
+//	srv := pokedextest.NewServer()
+//	defer srv.Close()
+//
+//	res, err := srv.Client().GetPokemon(ctx, pokedex.GetRequest{ID: 1})
+package pokedextest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdcurran/pokedex"
+	"github.com/mdcurran/pokedex/faker"
+	"github.com/mdcurran/pokedex/internal/defaults"
+	"github.com/mdcurran/pokedex/models"
+)
+
+type Options struct {
+	// Seed seeds the generated fake data, so the same seed always serves the
+	// same resources. If zero, different data is generated each time.
+	Seed int64
+	// Pokemon, Natures and Stats are the number of each resource generated.
+	// Natures and Stats are the PokéAPI's real ones in ID order, so at most 25
+	// Natures and 8 Stats are generated. If all are zero, 20 Pokémon and every
+	// Nature and Stat are generated. Resources can also be added as fixtures
+	// with Server.AddPokemon, etc.
+	Pokemon int
+	Natures int
+	Stats   int
+}
+
+// Server is a fake PokéAPI serving the /pokemon, /nature and /stat endpoints,
+// including their paginated named resource lists. It can be used by multiple
+// goroutines simultaneously.
+type Server struct {
+	URL string
+
+	srv    *httptest.Server
+	client *pokedex.Client
+
+	mu sync.Mutex
+	// resources holds the raw JSON of each resource in ID order, keyed by
+	// endpoint.
+	resources map[string][]resource
+	failures  map[string]int
+	latencies map[string]time.Duration
+	requests  map[string]int
+}
+
+type resource struct {
+	id   int
+	name string
+	body []byte
+}
+
+// NewServer starts a fake PokéAPI server with 20 generated Pokémon, and every
+// Nature and Stat.
+func NewServer() *Server {
+	return NewServerWithOptions(Options{})
+}
+
+// NewServerWithOptions starts a fake PokéAPI server with the provided
+// settings.
+func NewServerWithOptions(options Options) *Server {
+	if options.Pokemon == 0 && options.Natures == 0 && options.Stats == 0 {
+		options.Pokemon, options.Natures, options.Stats = 20, 25, 8
+	}
+
+	s := &Server{
+		resources: map[string][]resource{"pokemon": nil, "nature": nil, "stat": nil},
+		failures:  make(map[string]int),
+		latencies: make(map[string]time.Duration),
+		requests:  make(map[string]int),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+
+	f := faker.NewFakerWithOptions(faker.Options{Seed: options.Seed, BaseURL: s.URL})
+	for i := 1; i <= options.Pokemon; i++ {
+		s.AddPokemon(f.Pokemon(i))
+	}
+	natures := f.Natures()
+	for _, n := range natures[:min(options.Natures, len(natures))] {
+		s.AddNature(n)
+	}
+	stats := f.Stats()
+	for _, st := range stats[:min(options.Stats, len(stats))] {
+		s.AddStat(st)
+	}
+
+	client, err := pokedex.NewWithOptions(pokedex.Options{
+		BaseURL:          s.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         time.Minute,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("pokedextest: failed to create client: %v", err))
+	}
+	s.client = client
+
+	return s
+}
+
+// Client returns an SDK client configured to use the server. It's closed when
+// the server is closed.
+func (s *Server) Client() *pokedex.Client {
+	return s.client
+}
+
+// Close shuts down the server and its SDK client.
+func (s *Server) Close() {
+	s.client.Close()
+	s.srv.Close()
+}
+
+// AddPokemon adds a Pokémon to the server, replacing any with the same ID.
+func (s *Server) AddPokemon(p *models.Pokemon) {
+	s.add("pokemon", p.ID, p.Name, p)
+}
+
+// AddNature adds a Nature to the server, replacing any with the same ID.
+func (s *Server) AddNature(n *models.Nature) {
+	s.add("nature", n.ID, n.Name, n)
+}
+
+// AddStat adds a Stat to the server, replacing any with the same ID.
+func (s *Server) AddStat(st *models.Stat) {
+	s.add("stat", st.ID, st.Name, st)
+}
+
+func (s *Server) add(endpoint string, id int, name string, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("pokedextest: failed to encode %s %d: %v", endpoint, id, err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The resources are copied rather than modified in place, as lists being
+	// served read them without holding the lock.
+	r := resource{id: id, name: name, body: b}
+	resources := slices.Clone(s.resources[endpoint])
+	i, found := slices.BinarySearchFunc(resources, id, func(existing resource, id int) int {
+		return existing.id - id
+	})
+	if found {
+		resources[i] = r
+	} else {
+		resources = slices.Insert(resources, i, r)
+	}
+	s.resources[endpoint] = resources
+}
+
+// Fail makes every request to route respond with the given status. A route
+// is a path such as "/pokemon", which also matches every path beneath it,
+// such as "/pokemon/1", so "/" matches every request. If several routes match
+// a request, the most specific is used.
+func (s *Server) Fail(route string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[normalize(route)] = status
+}
+
+// Delay makes every request to route wait for d before responding. Like
+// Fail, the most specific matching route is used.
+func (s *Server) Delay(route string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[normalize(route)] = d
+}
+
+// Reset removes every failure and delay.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = make(map[string]int)
+	s.latencies = make(map[string]time.Duration)
+}
+
+// Requests returns the number of requests the server has received for route,
+// including every path beneath it.
+func (s *Server) Requests(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int
+	for path, count := range s.requests {
+		if matches(route, path) {
+			n += count
+		}
+	}
+	return n
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := "/" + strings.Trim(r.URL.Path, "/")
+
+	s.mu.Lock()
+	s.requests[path]++
+	status, _ := match(s.failures, path)
+	delay, _ := match(s.latencies, path)
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if status != 0 {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch len(segments) {
+	case 1:
+		s.serveList(w, r, segments[0])
+	case 2:
+		s.serveResource(w, segments[0], segments[1])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveResource(w http.ResponseWriter, endpoint, idOrName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.resources[endpoint] {
+		if strconv.Itoa(r.id) == idOrName || r.name == idOrName {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(r.body)
+			return
+		}
+	}
+	http.Error(w, "Not Found", http.StatusNotFound)
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, endpoint string) {
+	s.mu.Lock()
+	resources, ok := s.resources[endpoint]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaults.PageSize
+	}
+
+	list := models.NamedApiResourceList{
+		Count:   len(resources),
+		Results: []models.NamedApiResource{},
+	}
+	for i := offset; i < offset+limit && i < len(resources); i++ {
+		list.Results = append(list.Results, models.NamedApiResource{
+			Name: resources[i].name,
+			Url:  fmt.Sprintf("%s/%s/%d/", s.URL, endpoint, resources[i].id),
+		})
+	}
+	if offset+limit < len(resources) {
+		next := fmt.Sprintf("%s/%s?offset=%d&limit=%d", s.URL, endpoint, offset+limit, limit)
+		list.Next = &next
+	}
+	if offset > 0 {
+		previous := fmt.Sprintf("%s/%s?offset=%d&limit=%d", s.URL, endpoint, max(offset-limit, 0), limit)
+		list.Previous = &previous
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// matches reports whether path is route or beneath it. Every path is beneath
+// the root route "/".
+func matches(route, path string) bool {
+	route = normalize(route)
+	if route == "/" {
+		return true
+	}
+	return path == route || strings.HasPrefix(path, route+"/")
+}
+
+// match returns the value of the longest, and so most specific, route in
+// routes that matches path.
+func match[V any](routes map[string]V, path string) (V, bool) {
+	var (
+		v    V
+		best = -1
+	)
+	for route, rv := range routes {
+		if matches(route, path) && len(route) > best {
+			v, best = rv, len(route)
+		}
+	}
+	return v, best >= 0
+}
+
+func normalize(route string) string {
+	return "/" + strings.Trim(route, "/")
+}
@@ -0,0 +1,213 @@
+package pokedextest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex"
+	"github.com/mdcurran/pokedex/faker"
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Seed: 1, Pokemon: 25, Natures: 3, Stats: 1})
+	t.Cleanup(srv.Close)
+	sdk := srv.Client()
+
+	res, err := sdk.GetPokemon(ctx, pokedex.GetRequest{ID: 7})
+	require.NoError(t, err)
+	require.Equal(t, 7, res.Pokemon.ID)
+
+	byName, err := sdk.GetPokemon(ctx, pokedex.GetRequest{Name: res.Pokemon.Name})
+	require.NoError(t, err)
+	require.Equal(t, res.Pokemon, byName.Pokemon)
+
+	_, err = sdk.GetPokemon(ctx, pokedex.GetRequest{ID: 26})
+	require.ErrorIs(t, err, pokedex.KindNotFound)
+
+	// The same seed generates the same data.
+	other := NewServerWithOptions(Options{Seed: 1, Pokemon: 25, Natures: 3, Stats: 1})
+	t.Cleanup(other.Close)
+	otherRes, err := other.Client().GetPokemon(ctx, pokedex.GetRequest{ID: 7})
+	require.NoError(t, err)
+	require.Equal(t, res.Pokemon.Name, otherRes.Pokemon.Name)
+
+	list, err := sdk.ListPokemon(ctx, pokedex.ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	var ids []int
+	for {
+		page, err := list.Iterator.Next(ctx)
+		if err == iterator.EndOfIterator {
+			break
+		}
+		require.NoError(t, err)
+		for _, p := range page {
+			ids = append(ids, p.ID)
+		}
+	}
+	require.Len(t, ids, 25)
+	require.Equal(t, 1, ids[0])
+	require.Equal(t, 25, ids[24])
+}
+
+func TestServer_Fixtures(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Stats: 2})
+	t.Cleanup(srv.Close)
+
+	srv.AddNature(&models.Nature{ID: 2, Name: "bold"})
+	srv.AddNature(&models.Nature{ID: 1, Name: "hardy"})
+
+	res, err := srv.Client().ListNatures(ctx, pokedex.ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	natures, err := res.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, natures, 2)
+	require.Equal(t, "hardy", natures[0].Name)
+	require.Equal(t, "bold", natures[1].Name)
+}
+
+func TestServer_Failures(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Stats: 2})
+	t.Cleanup(srv.Close)
+	sdk := srv.Client()
+
+	srv.Fail("/stat/1", http.StatusTooManyRequests)
+	_, err := sdk.GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.ErrorIs(t, err, pokedex.KindRateLimited)
+	_, err = sdk.GetStat(ctx, pokedex.GetRequest{ID: 2})
+	require.NoError(t, err)
+
+	srv.Reset()
+	srv.Delay("/stat", 50*time.Millisecond)
+	start := time.Now()
+	_, err = sdk.GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	require.Equal(t, 3, srv.Requests("/stat"))
+	require.Equal(t, 2, srv.Requests("/stat/1"))
+}
+
+func TestServer_PartialResults(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Stats: 8})
+	t.Cleanup(srv.Close)
+	sdk := srv.Client()
+
+	// Every Stat in the first page fails, but the rest of the list is still
+	// returned.
+	srv.Fail("/stat/hp", http.StatusInternalServerError)
+	srv.Fail("/stat/attack", http.StatusInternalServerError)
+
+	res, err := sdk.ListStats(ctx, pokedex.ListRequest{PageSize: 2, PartialResults: true})
+	require.NoError(t, err)
+
+	var (
+		names []string
+		errs  int
+	)
+	for stat, err := range res.All(ctx) {
+		if err != nil {
+			var pageErr *pokedex.PageError
+			require.ErrorAs(t, err, &pageErr)
+			require.Len(t, pageErr.Errors, 2)
+			errs++
+			continue
+		}
+		names = append(names, stat.Name)
+	}
+	require.Equal(t, 1, errs)
+	require.Len(t, names, 6)
+	require.NotContains(t, names, "hp")
+	require.NotContains(t, names, "attack")
+}
+
+func TestServer_RootRoute(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Pokemon: 1, Stats: 1})
+	t.Cleanup(srv.Close)
+	sdk := srv.Client()
+
+	// The root route matches every request.
+	srv.Fail("/", http.StatusServiceUnavailable)
+	_, err := sdk.GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.ErrorIs(t, err, pokedex.KindUnexpectedStatus)
+	_, err = sdk.GetPokemon(ctx, pokedex.GetRequest{ID: 1})
+	require.ErrorIs(t, err, pokedex.KindUnexpectedStatus)
+
+	srv.Reset()
+	srv.Delay("/", 50*time.Millisecond)
+	start := time.Now()
+	_, err = sdk.GetPokemon(ctx, pokedex.GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	require.Equal(t, 3, srv.Requests("/"))
+}
+
+func TestServer_MostSpecificRoute(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Pokemon: 2})
+	t.Cleanup(srv.Close)
+	sdk := srv.Client()
+
+	srv.Fail("/pokemon", http.StatusInternalServerError)
+	srv.Fail("/pokemon/1/", http.StatusNotFound)
+	srv.Delay("pokemon", time.Second)
+	srv.Delay("/pokemon/1", 0)
+	for i := 0; i < 50; i++ {
+		_, err := sdk.GetPokemon(ctx, pokedex.GetRequest{ID: 1})
+		require.ErrorIs(t, err, pokedex.KindNotFound)
+	}
+
+	srv.Delay("/pokemon", 0)
+	_, err := sdk.GetPokemon(ctx, pokedex.GetRequest{ID: 2})
+	require.ErrorIs(t, err, pokedex.KindUnexpectedStatus)
+}
+
+func TestServer_AddWhileListing(t *testing.T) {
+	ctx := context.Background()
+
+	srv := NewServerWithOptions(Options{Pokemon: 5})
+	t.Cleanup(srv.Close)
+	sdk := srv.Client()
+	f := faker.NewFakerWithOptions(faker.Options{Seed: 1, BaseURL: srv.URL})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 50; i > 0; i-- {
+			srv.AddPokemon(f.Pokemon(i))
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		res, err := sdk.ListPokemonRefs(ctx, pokedex.ListRequest{PageSize: 100})
+		require.NoError(t, err)
+		_, err = res.Iterator.Next(ctx)
+		require.NoError(t, err)
+	}
+	<-done
+
+	res, err := sdk.ListPokemonRefs(ctx, pokedex.ListRequest{PageSize: 100})
+	require.NoError(t, err)
+	refs, err := res.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, refs, 50)
+	for i, ref := range refs {
+		require.Equal(t, fmt.Sprintf("%s/pokemon/%d/", srv.URL, i+1), ref.Url)
+	}
+}
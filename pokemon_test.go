@@ -9,7 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/mdcurran/pokedex/internal/faker"
+	"github.com/mdcurran/pokedex/faker"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,5 +40,5 @@ func TestGetPokemon(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, string(fixture), string(serialised))
 
-	require.Len(t, res.Pokemon.Abilities, 1)
+	require.Len(t, res.Pokemon.Stats, 6)
 }
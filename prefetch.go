@@ -0,0 +1,97 @@
+package pokedex
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mdcurran/pokedex/internal/defaults"
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+)
+
+// PrefetchProgress reports how far Prefetch has got through a resource's
+// collection.
+type PrefetchProgress struct {
+	// Resource is the name of the endpoint being prefetched, for example
+	// "pokemon".
+	Resource string
+	// Fetched is the number of resources fetched so far, including any that
+	// were already cached.
+	Fetched int
+	Total   int
+}
+
+type PrefetchOptions struct {
+	// PageSize is the number of resources requested per page of the named
+	// resource list. Defaults to 100.
+	PageSize uint
+	// OnProgress is called each time a resource has been fetched. It's never
+	// called concurrently.
+	OnProgress func(PrefetchProgress)
+}
+
+// Prefetch warms the cache with every resource of the named endpoints, for
+// example "pokemon" or "nature". Resources already in the cache aren't
+// fetched again, so if Prefetch is cancelled calling it again resumes where
+// it left off.
+func (c *Client) Prefetch(ctx context.Context, resources ...string) error {
+	return c.PrefetchWithOptions(ctx, PrefetchOptions{}, resources...)
+}
+
+// PrefetchWithOptions is Prefetch with control over its page size and
+// progress reporting. At most Options.Concurrency resources are fetched at
+// once.
+func (c *Client) PrefetchWithOptions(ctx context.Context, options PrefetchOptions, resources ...string) error {
+	if options.PageSize == 0 {
+		options.PageSize = defaults.CrawlPageSize
+	}
+
+	for _, resource := range resources {
+		err := c.prefetch(ctx, resource, options)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefetch walks the named resource list of a single endpoint, fetching each
+// resource with bounded concurrency. The first error cancels any outstanding
+// requests and is returned.
+func (c *Client) prefetch(ctx context.Context, resource string, options PrefetchOptions) error {
+	var (
+		mu       sync.Mutex
+		progress = PrefetchProgress{Resource: resource}
+	)
+
+	res, err := c.ListRefs(ctx, resource, ListRequest{
+		PageSize: options.PageSize,
+		Progress: func(p iterator.Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress.Total = p.Total
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return iterator.ForEach(ctx, res.All(ctx), c.concurrency, func(ctx context.Context, ref models.NamedApiResource) error {
+		name, err := refName(ref)
+		if err != nil {
+			return err
+		}
+		_, err = c.getRaw(ctx, resource, name)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		progress.Fetched++
+		if options.OnProgress != nil {
+			options.OnProgress(progress)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,212 @@
+package pokedex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex/models"
+	"github.com/stretchr/testify/require"
+)
+
+// newListServer serves a named resource list of count natures, paginated
+// with offset and limit, and each nature individually. The number of requests
+// for individual natures is recorded.
+func newListServer(t *testing.T, count int, requests *atomic.Int64) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/nature" {
+			requests.Add(1)
+			name := strings.TrimPrefix(r.URL.Path, "/nature/")
+			fmt.Fprintf(w, `{"name": %q}`, name)
+			return
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		list := models.NamedApiResourceList{Count: count, Results: []models.NamedApiResource{}}
+		for i := offset; i < offset+limit && i < count; i++ {
+			list.Results = append(list.Results, models.NamedApiResource{Name: fmt.Sprintf("nature-%d", i)})
+		}
+		if offset+limit < count {
+			next := fmt.Sprintf("%s/nature?offset=%d&limit=%d", "http://"+r.Host, offset+limit, limit)
+			list.Next = &next
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPrefetch(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := newListServer(t, 25, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		Concurrency:      4,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	var last PrefetchProgress
+	err = sdk.PrefetchWithOptions(ctx, PrefetchOptions{
+		PageSize:   10,
+		OnProgress: func(p PrefetchProgress) { last = p },
+	}, "nature")
+	require.NoError(t, err)
+	require.Equal(t, PrefetchProgress{Resource: "nature", Fetched: 25, Total: 25}, last)
+	require.Equal(t, int64(25), requests.Load())
+
+	// Every nature is now cached, so no further requests are made.
+	res, err := sdk.ListNatures(ctx, ListRequest{PageSize: 25})
+	require.NoError(t, err)
+	natures, err := res.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, natures, 25)
+	require.Equal(t, int64(25), requests.Load())
+}
+
+func TestPrefetch_Resume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var requests atomic.Int64
+	srv := newListServer(t, 30, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		Concurrency:      1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	// Cancel the prefetch part-way through.
+	err = sdk.PrefetchWithOptions(ctx, PrefetchOptions{
+		PageSize: 10,
+		OnProgress: func(p PrefetchProgress) {
+			if p.Fetched == 12 {
+				cancel()
+			}
+		},
+	}, "nature")
+	require.ErrorIs(t, err, context.Canceled)
+	fetched := requests.Load()
+	require.Less(t, fetched, int64(30))
+
+	// Prefetching again only fetches the natures that aren't cached yet.
+	var last PrefetchProgress
+	err = sdk.PrefetchWithOptions(context.Background(), PrefetchOptions{
+		PageSize:   10,
+		OnProgress: func(p PrefetchProgress) { last = p },
+	}, "nature")
+	require.NoError(t, err)
+	require.Equal(t, 30, last.Fetched)
+	require.LessOrEqual(t, requests.Load(), int64(31))
+}
+
+// newUnnamedServer serves a named resource list of two characteristics, which
+// have no names, so their refs only have a URL, and each characteristic
+// individually. The path of every request is recorded in paths.
+func newUnnamedServer(t *testing.T, mu *sync.Mutex, paths *[]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*paths = append(*paths, r.URL.Path)
+		mu.Unlock()
+
+		if r.URL.Path == "/characteristic" && r.URL.Query().Get("offset") != "0" {
+			fmt.Fprint(w, `{"count": 2, "next": null, "previous": null, "results": []}`)
+			return
+		}
+		if r.URL.Path == "/characteristic" {
+			fmt.Fprintf(w, `{"count": 2, "next": null, "previous": null, "results": [
+				{"url": "http://%[1]s/characteristic/1/"},
+				{"url": "http://%[1]s/characteristic/2/"}
+			]}`, r.Host)
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/characteristic/")
+		fmt.Fprintf(w, `{"id": %s}`, id)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPrefetch_Unnamed(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	srv := newUnnamedServer(t, &mu, &paths)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	err = sdk.Prefetch(ctx, "characteristic")
+	require.NoError(t, err)
+
+	// Each characteristic is cached under its ID, so it isn't fetched again.
+	b, err := sdk.GetRaw(ctx, "characteristic", GetRequest{ID: 2})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": 2}`, string(b))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.ElementsMatch(t, []string{"/characteristic", "/characteristic", "/characteristic/1", "/characteristic/2"}, paths)
+}
+
+func TestResourceList_Unnamed(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	srv := newUnnamedServer(t, &mu, &paths)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	type characteristic struct {
+		ID int `json:"id"`
+	}
+	it, err := NewResource[characteristic](sdk, "characteristic").List(ctx, ListRequest{PageSize: 2})
+	require.NoError(t, err)
+
+	// Each characteristic is fetched by the ID in its URL.
+	chars, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*characteristic{{ID: 1}, {ID: 2}}, chars)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.ElementsMatch(t, []string{"/characteristic", "/characteristic/1", "/characteristic/2"}, paths)
+}
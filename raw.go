@@ -0,0 +1,97 @@
+package pokedex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+)
+
+// GetRaw returns the raw JSON body of a single resource of any endpoint, for
+// example GetRaw(ctx, "pokemon", GetRequest{ID: 1}). The body is cached like
+// any other response.
+func (c *Client) GetRaw(ctx context.Context, resource string, r GetRequest) ([]byte, error) {
+	name, err := r.GetResource()
+	if err != nil {
+		return nil, err
+	}
+	return c.getRaw(ctx, resource, name)
+}
+
+// getRaw fetches a single resource and adds it to the cache. The response
+// body isn't decoded into a model, it's only validated as JSON.
+func (c *Client) getRaw(ctx context.Context, resource, name string) ([]byte, error) {
+	u := c.baseURL.JoinPath(resource, name)
+
+	b, res, err := c.fetch(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+	// A nil response means the body was read from the cache.
+	if res == nil {
+		return b, nil
+	}
+	if !json.Valid(b) {
+		return nil, newRequestError(KindDecode, errors.New("invalid JSON response body"), u.String(), res)
+	}
+	c.cacheSet(ctx, u.String(), b)
+
+	return b, nil
+}
+
+type ListRefsResponse struct {
+	// Iterator iterates over the named resource list. It can be replaced,
+	// for example by an iterator.Slice in tests.
+	Iterator iterator.Iterator[models.NamedApiResource]
+}
+
+// Paginator returns the Iterator as a Paginator, for the methods specific to
+// pagination such as Token and Seek. It returns nil if the Iterator has been
+// replaced by another implementation.
+func (r *ListRefsResponse) Paginator() *iterator.Paginator[models.NamedApiResource] {
+	p, _ := r.Iterator.(*iterator.Paginator[models.NamedApiResource])
+	return p
+}
+
+// All returns an iterator over each remaining ref of the Iterator, for use
+// with range. See iterator.All.
+func (r *ListRefsResponse) All(ctx context.Context) iter.Seq2[models.NamedApiResource, error] {
+	return iterator.All(ctx, r.Iterator)
+}
+
+// Pages returns an iterator over the remaining pages of the Iterator, for use
+// with range. See iterator.Pages.
+func (r *ListRefsResponse) Pages(ctx context.Context) iter.Seq2[[]models.NamedApiResource, error] {
+	return iterator.Pages(ctx, r.Iterator)
+}
+
+// ListRefs returns an iterator with a user-provided page size over the named
+// resource list of any endpoint, for example "pokemon". Unlike the List
+// methods, each resource isn't fetched.
+func (c *Client) ListRefs(ctx context.Context, resource string, r ListRequest) (*ListRefsResponse, error) {
+	it, err := listRefs(ctx, c, resource, r)
+	if err != nil {
+		return nil, err
+	}
+	return &ListRefsResponse{Iterator: it}, nil
+}
+
+// listRefs returns a Paginator over the named resource list of resource,
+// without fetching each resource.
+func listRefs(ctx context.Context, c *Client, resource string, r ListRequest) (*iterator.Paginator[models.NamedApiResource], error) {
+	it, err := iterator.NewPaginatorWithPages(ctx, r.paginatorOptions(resource), func(ctx context.Context, start, end uint) (iterator.Page[models.NamedApiResource], error) {
+		resourceList, err := c.fetchResourceList(ctx, resource, start, end-start)
+		if err != nil {
+			return iterator.Page[models.NamedApiResource]{Total: -1}, err
+		}
+		return iterator.Page[models.NamedApiResource]{Results: resourceList.Results, Total: resourceList.Count}, nil
+	})
+	if err != nil {
+		return nil, NewError(KindInvalidArgs, err, nil)
+	}
+
+	return it, nil
+}
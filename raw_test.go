@@ -0,0 +1,57 @@
+package pokedex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRaw(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/move" && r.URL.Query().Get("offset") == "0":
+			fmt.Fprint(w, `{"count": 1, "next": null, "previous": null, "results": [{"name": "pound", "url": ""}]}`)
+		case r.URL.Path == "/move":
+			fmt.Fprint(w, `{"count": 1, "next": null, "previous": null, "results": []}`)
+		case r.URL.Path == "/move/1":
+			fmt.Fprint(w, `{"id": 1, "name": "pound", "power": 40}`)
+		default:
+			fmt.Fprint(w, `{"id":`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	// Endpoints without a model can still be read.
+	b, err := sdk.GetRaw(ctx, "move", GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": 1, "name": "pound", "power": 40}`, string(b))
+
+	_, err = sdk.GetRaw(ctx, "move", GetRequest{ID: 2})
+	require.ErrorIs(t, err, KindDecode)
+
+	res, err := sdk.ListRefs(ctx, "move", ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	refs, err := res.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	require.Equal(t, "pound", refs[0].Name)
+	_, err = res.Iterator.Next(ctx)
+	require.ErrorIs(t, err, iterator.EndOfIterator)
+}
@@ -0,0 +1,152 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Secret", "hunter2")
+		switch r.URL.Path {
+		case "/stat/1":
+			fmt.Fprint(w, `{"id": 1, "name": "hp"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "Not Found")
+		}
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "cassettes", "stat.json")
+
+	rec, err := New(cassette, Options{
+		Mode: ModeRecord,
+		Redact: func(i *Interaction) {
+			i.Response.Headers.Del("X-Secret")
+		},
+	})
+	require.NoError(t, err)
+
+	sdk := newClient(t, srv.URL, rec)
+	res, err := sdk.GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "hp", res.Stat.Name)
+	_, err = sdk.GetStat(ctx, pokedex.GetRequest{ID: 2})
+	require.ErrorIs(t, err, pokedex.KindNotFound)
+
+	require.NoError(t, rec.Save())
+	require.Len(t, rec.Interactions(), 2)
+
+	// Replay the cassette once the server has gone away.
+	srv.Close()
+
+	rec, err = New(cassette, Options{Mode: ModeReplay})
+	require.NoError(t, err)
+	for _, i := range rec.Interactions() {
+		require.Empty(t, i.Response.Headers.Get("X-Secret"))
+	}
+
+	sdk = newClient(t, srv.URL, rec)
+	res, err = sdk.GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "hp", res.Stat.Name)
+	_, err = sdk.GetStat(ctx, pokedex.GetRequest{ID: 2})
+	require.ErrorIs(t, err, pokedex.KindNotFound)
+
+	// Requests that weren't recorded fail.
+	_, err = sdk.GetStat(ctx, pokedex.GetRequest{ID: 3})
+	require.ErrorIs(t, err, ErrNoInteraction)
+	require.ErrorIs(t, err, pokedex.KindTransport)
+}
+
+func TestRecorder_RedactURL(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "hp"}`)
+	}))
+
+	// The API key is added to every request's URL, and stripped from the
+	// cassette.
+	withKey := func(next http.RoundTripper) http.RoundTripper {
+		return pokedex.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			r = r.Clone(r.Context())
+			q := r.URL.Query()
+			q.Set("api_key", "hunter2")
+			r.URL.RawQuery = q.Encode()
+			return next.RoundTrip(r)
+		})
+	}
+	redact := func(i *Interaction) {
+		u, err := url.Parse(i.Request.URL)
+		require.NoError(t, err)
+		q := u.Query()
+		q.Del("api_key")
+		u.RawQuery = q.Encode()
+		i.Request.URL = u.String()
+	}
+	newClient := func(rec *Recorder) *pokedex.Client {
+		sdk, err := pokedex.NewWithOptions(pokedex.Options{
+			BaseURL:          srv.URL,
+			Timeout:          5 * time.Second,
+			CacheMaximumSize: 1 << 20,
+			CacheTTL:         10 * time.Second,
+			Transport:        rec,
+			Middleware:       []pokedex.Middleware{withKey},
+		})
+		require.NoError(t, err)
+		t.Cleanup(sdk.Close)
+		return sdk
+	}
+
+	cassette := filepath.Join(t.TempDir(), "stat.json")
+	rec, err := New(cassette, Options{Mode: ModeRecord, Redact: redact})
+	require.NoError(t, err)
+	_, err = newClient(rec).GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+	srv.Close()
+
+	rec, err = New(cassette, Options{Mode: ModeReplay, Redact: redact})
+	require.NoError(t, err)
+	require.Len(t, rec.Interactions(), 1)
+	require.NotContains(t, rec.Interactions()[0].Request.URL, "hunter2")
+
+	// The incoming request is redacted the same way, so it matches.
+	res, err := newClient(rec).GetStat(ctx, pokedex.GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "hp", res.Stat.Name)
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Options{Mode: ModeReplay})
+	require.Error(t, err)
+}
+
+func newClient(t *testing.T, baseURL string, rec *Recorder) *pokedex.Client {
+	sdk, err := pokedex.NewWithOptions(pokedex.Options{
+		BaseURL:          baseURL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		Transport:        rec,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+	return sdk
+}
@@ -0,0 +1,108 @@
+package pokedex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+)
+
+// Resource is a PokéAPI endpoint whose resources are decoded into T, for
+// example:
+//
+//	berries := pokedex.NewResource[Berry](sdk, "berry")
+//	berry, err := berries.Get(ctx, pokedex.GetRequest{Name: "cheri"})
+//
+// The Get* and List* methods of the client are built on a Resource for each
+// endpoint. A Resource is cheap to create and is safe to use by multiple
+// goroutines simultaneously.
+type Resource[T any] struct {
+	client   *Client
+	endpoint string
+}
+
+// NewResource returns the Resource for the endpoint, for example "pokemon".
+func NewResource[T any](c *Client, endpoint string) *Resource[T] {
+	return &Resource[T]{client: c, endpoint: endpoint}
+}
+
+// Get returns a single resource according to an ID or name.
+func (r *Resource[T]) Get(ctx context.Context, req GetRequest) (*T, error) {
+	resource, err := req.GetResource()
+	if err != nil {
+		return nil, err
+	}
+	return r.get(ctx, resource)
+}
+
+// List returns an iterator with a user-provided page size over all resources
+// of the endpoint.
+func (r *Resource[T]) List(ctx context.Context, req ListRequest) (*iterator.Paginator[*T], error) {
+	it, err := iterator.NewPaginatorWithPages(ctx, req.paginatorOptions(r.endpoint), func(ctx context.Context, start, end uint) (iterator.Page[*T], error) {
+		return listPage(ctx, r.client, r.endpoint, start, end, req.PartialResults, r.get)
+	})
+	if err != nil {
+		return nil, NewError(KindInvalidArgs, err, nil)
+	}
+
+	return it, nil
+}
+
+// ListRefs returns an iterator with a user-provided page size over the named
+// resource list of the endpoint. Unlike List, each resource isn't fetched, so
+// it only makes one request per page. A ref can be fetched later with
+// Hydrate.
+func (r *Resource[T]) ListRefs(ctx context.Context, req ListRequest) (*iterator.Paginator[models.NamedApiResource], error) {
+	return listRefs(ctx, r.client, r.endpoint, req)
+}
+
+// Hydrate returns the resource a ref returned by ListRefs points to. The ref
+// is looked up by its name, or the ID at the end of its URL if it has no
+// name.
+func (r *Resource[T]) Hydrate(ctx context.Context, ref models.NamedApiResource) (*T, error) {
+	name, err := refName(ref)
+	if err != nil {
+		return nil, err
+	}
+	return r.get(ctx, name)
+}
+
+// refName returns the name a ref is fetched by. Refs of unnamed endpoints,
+// such as "characteristic" or "evolution-chain", only have a URL, so the ID at
+// the end of it is used instead.
+func refName(ref models.NamedApiResource) (string, error) {
+	name := ref.Name
+	if name == "" {
+		name = path.Base(strings.TrimSuffix(ref.Url, "/"))
+	}
+	if name == "" || name == "." || name == "/" {
+		return "", NewError(KindInvalidArgs, errors.New("ref has no name or URL"), nil)
+	}
+	return name, nil
+}
+
+func (r *Resource[T]) get(ctx context.Context, resource string) (*T, error) {
+	u := r.client.baseURL.JoinPath(r.endpoint, resource)
+
+	b, res, err := r.client.fetch(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var v *T
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, newRequestError(KindDecode, err, u.String(), res)
+	}
+	// A nil response means the body was read from the cache.
+	if res == nil {
+		return v, nil
+	}
+	r.client.cacheSet(ctx, u.String(), b)
+
+	return v, nil
+}
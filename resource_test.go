@@ -0,0 +1,254 @@
+package pokedex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+	"github.com/stretchr/testify/require"
+)
+
+type move struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Power int    `json:"power"`
+}
+
+func TestResource(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/move" && r.URL.Query().Get("offset") == "0":
+			fmt.Fprint(w, `{"count": 2, "next": null, "previous": null, "results": [{"name": "pound", "url": ""}, {"name": "karate-chop", "url": ""}]}`)
+		case r.URL.Path == "/move":
+			fmt.Fprint(w, `{"count": 2, "next": null, "previous": null, "results": []}`)
+		case r.URL.Path == "/move/1", r.URL.Path == "/move/pound":
+			fmt.Fprint(w, `{"id": 1, "name": "pound", "power": 40}`)
+		case r.URL.Path == "/move/karate-chop":
+			fmt.Fprint(w, `{"id": 2, "name": "karate-chop", "power": 50}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	moves := NewResource[move](sdk, "move")
+
+	m, err := moves.Get(ctx, GetRequest{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &move{ID: 1, Name: "pound", Power: 40}, m)
+
+	_, err = moves.Get(ctx, GetRequest{ID: 3})
+	require.ErrorIs(t, err, KindNotFound)
+	_, err = moves.Get(ctx, GetRequest{})
+	require.ErrorIs(t, err, KindInvalidArgs)
+
+	it, err := moves.List(ctx, ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	page, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, 50, page[1].Power)
+	_, err = it.Next(ctx)
+	require.ErrorIs(t, err, iterator.EndOfIterator)
+}
+
+func TestResource_PageToken(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := newListServer(t, 25, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.ListNatures(ctx, ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	_, err = res.Iterator.Next(ctx)
+	require.NoError(t, err)
+	token := res.Paginator().Token()
+
+	// A new list resumes from the second page.
+	resumed, err := sdk.ListNatures(ctx, ListRequest{PageSize: 10, PageToken: token})
+	require.NoError(t, err)
+	natures, err := resumed.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "nature-10", natures[0].Name)
+
+	_, err = sdk.ListNatures(ctx, ListRequest{PageSize: 20, PageToken: token})
+	require.ErrorIs(t, err, KindInvalidArgs)
+	require.ErrorIs(t, err, iterator.ErrInvalidToken)
+	_, err = sdk.ListStats(ctx, ListRequest{PageSize: 10, PageToken: token})
+	require.ErrorIs(t, err, KindInvalidArgs)
+	_, err = sdk.ListRefs(ctx, "nature", ListRequest{PageSize: 10, PageToken: "garbage"})
+	require.ErrorIs(t, err, KindInvalidArgs)
+}
+
+func TestResource_Progress(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := newListServer(t, 25, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	var progress []iterator.Progress
+	res, err := sdk.ListNatures(ctx, ListRequest{PageSize: 10, Progress: func(p iterator.Progress) {
+		progress = append(progress, p)
+	}})
+	require.NoError(t, err)
+	require.Equal(t, -1, res.Paginator().Total())
+	require.True(t, res.Paginator().HasNext())
+
+	_, err = res.Iterator.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 25, res.Paginator().Total())
+	require.Equal(t, uint(10), res.Paginator().Offset())
+
+	for _, err := range res.Pages(ctx) {
+		require.NoError(t, err)
+	}
+	require.False(t, res.Paginator().HasNext())
+	require.Equal(t, []iterator.Progress{
+		{Page: 1, Pages: 3, Offset: 10, Total: 25},
+		{Page: 2, Pages: 3, Offset: 20, Total: 25},
+		{Page: 3, Pages: 3, Offset: 25, Total: 25},
+	}, progress)
+}
+
+func TestResource_ListRefs(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := newListServer(t, 25, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.ListNatureRefs(ctx, ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	var refs []models.NamedApiResource
+	for ref, err := range res.All(ctx) {
+		require.NoError(t, err)
+		refs = append(refs, ref)
+	}
+	require.Len(t, refs, 25)
+	require.Equal(t, "nature-24", refs[24].Name)
+	// No resource was fetched.
+	require.Zero(t, requests.Load())
+
+	nature, err := sdk.HydrateNature(ctx, refs[3])
+	require.NoError(t, err)
+	require.Equal(t, "nature-3", nature.Nature.Name)
+	require.Equal(t, int64(1), requests.Load())
+
+	// A ref without a name is fetched by the ID in its URL.
+	nature, err = sdk.HydrateNature(ctx, models.NamedApiResource{Url: "https://pokeapi.co/api/v2/nature/7/"})
+	require.NoError(t, err)
+	require.Equal(t, "7", nature.Nature.Name)
+
+	_, err = sdk.HydrateNature(ctx, models.NamedApiResource{})
+	require.ErrorIs(t, err, KindInvalidArgs)
+}
+
+func TestResource_OffsetLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := newListServer(t, 300, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	res, err := sdk.ListNatures(ctx, ListRequest{PageSize: 20, Offset: 151, Limit: 100})
+	require.NoError(t, err)
+	var names []string
+	for nature, err := range res.All(ctx) {
+		require.NoError(t, err)
+		names = append(names, nature.Name)
+	}
+	require.Len(t, names, 100)
+	require.Equal(t, "nature-151", names[0])
+	require.Equal(t, "nature-250", names[99])
+	// Only the resources in range are fetched.
+	require.Equal(t, int64(100), requests.Load())
+}
+
+func TestResource_DefaultPageSize(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int64
+	srv := newListServer(t, 45, &requests)
+
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	// Without a PageSize, the list is fetched in pages of 20 rather than
+	// ending straight away.
+	res, err := sdk.ListNatureRefs(ctx, ListRequest{})
+	require.NoError(t, err)
+	var pages []int
+	for page, err := range res.Pages(ctx) {
+		require.NoError(t, err)
+		pages = append(pages, len(page))
+	}
+	require.Equal(t, []int{20, 20, 5}, pages)
+}
+
+func TestListResponse_ReplacedIterator(t *testing.T) {
+	ctx := context.Background()
+
+	// Code consuming a list can be tested with an in-memory iterator.
+	res := &ListNaturesResponse{Iterator: iterator.NewSlice(2, []*models.Nature{{Name: "bold"}, {Name: "calm"}, {Name: "mild"}})}
+	natures, err := iterator.Collect(res.All(ctx))
+	require.NoError(t, err)
+	require.Len(t, natures, 3)
+	require.Nil(t, res.Paginator())
+}
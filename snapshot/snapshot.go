@@ -0,0 +1,182 @@
+// Package snapshot exports PokéAPI resources to a directory laid out like the
+// PokeAPI/api-data repository, so reproducible snapshots of the API can be
+// kept as test fixtures or served with the SDK client's offline mode.
+//
+// Each resource is written to api/v2/<resource>/<id>/index.json, and the
+// complete named resource list of each endpoint to api/v2/<resource>/index.json.
+// Like api-data, the URLs in them are relative to the API root, such as
+// /api/v2/pokemon/1/.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mdcurran/pokedex"
+	"github.com/mdcurran/pokedex/internal/defaults"
+	"github.com/mdcurran/pokedex/iterator"
+	"github.com/mdcurran/pokedex/models"
+)
+
+type Options struct {
+	// PageSize is the number of resources requested per page of each named
+	// resource list, and fetched together. Defaults to 100.
+	PageSize uint
+}
+
+// Export crawls every resource of the given endpoints, for example "pokemon",
+// "nature" and "stat", and writes them to dir. At most the client's
+// Options.Concurrency resources are fetched at once.
+func Export(ctx context.Context, client *pokedex.Client, dir string, resources ...string) error {
+	return ExportWithOptions(ctx, client, dir, Options{}, resources...)
+}
+
+// ExportWithOptions is Export with control over its page size.
+func ExportWithOptions(ctx context.Context, client *pokedex.Client, dir string, options Options, resources ...string) error {
+	if options.PageSize == 0 {
+		options.PageSize = defaults.CrawlPageSize
+	}
+
+	for _, resource := range resources {
+		err := export(ctx, client, filepath.Join(dir, "api", "v2", resource), resource, options)
+		if err != nil {
+			return fmt.Errorf("export %s: %w", resource, err)
+		}
+	}
+	return nil
+}
+
+func export(ctx context.Context, client *pokedex.Client, dir, resource string, options Options) error {
+	refs, err := listRefs(ctx, client, resource, options.PageSize)
+	if err != nil {
+		return err
+	}
+
+	// The URLs in the named resource list and each resource are written
+	// relative to the API root, like api-data, so snapshots don't depend on
+	// the host they were taken from.
+	var root string
+	list := models.NamedApiResourceList{Count: len(refs), Results: []models.NamedApiResource{}}
+	for _, ref := range refs {
+		id, err := refID(ref)
+		if err != nil {
+			return err
+		}
+		if root == "" {
+			root = apiRoot(ref.Url, resource, id)
+		}
+		list.Results = append(list.Results, models.NamedApiResource{
+			Name: ref.Name,
+			Url:  "/" + path.Join("api", "v2", resource, strconv.Itoa(id)) + "/",
+		})
+	}
+
+	for page, err := range iterator.NewSlice(options.PageSize, list.Results).Pages(ctx) {
+		if err != nil {
+			return err
+		}
+		err = exportResources(ctx, client, dir, resource, root, page)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeJSON(filepath.Join(dir, "index.json"), list)
+}
+
+// listRefs reads the complete named resource list of an endpoint.
+func listRefs(ctx context.Context, client *pokedex.Client, resource string, pageSize uint) ([]models.NamedApiResource, error) {
+	res, err := client.ListRefs(ctx, resource, pokedex.ListRequest{PageSize: pageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []models.NamedApiResource
+	for {
+		page, err := res.Iterator.Next(ctx)
+		if err == iterator.EndOfIterator {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, page...)
+	}
+	return refs, nil
+}
+
+// exportResources fetches a page of resources together and writes each of
+// them, replacing root at the start of each URL in them with /api/v2/.
+func exportResources(ctx context.Context, client *pokedex.Client, dir, resource, root string, refs []models.NamedApiResource) error {
+	reqs := make([]pokedex.GetRequest, len(refs))
+	for i, ref := range refs {
+		id, err := refID(ref)
+		if err != nil {
+			return err
+		}
+		reqs[i] = pokedex.GetRequest{ID: id}
+	}
+
+	results := pokedex.NewResource[json.RawMessage](client, resource).BatchGet(ctx, reqs)
+	for _, res := range results {
+		if res.Err != nil {
+			return res.Err
+		}
+		b := []byte(*res.Value)
+		if root != "" {
+			b = bytes.ReplaceAll(b, []byte(`"`+root), []byte(`"/api/v2/`))
+		}
+		err := writeFile(filepath.Join(dir, strconv.Itoa(res.Request.ID), "index.json"), b)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apiRoot returns the API root of a resource's URL, such as
+// https://pokeapi.co/api/v2/ in https://pokeapi.co/api/v2/pokemon/1/, or ""
+// if the URL doesn't end in the resource's path.
+func apiRoot(url, resource string, id int) string {
+	url = strings.TrimSuffix(url, "/") + "/"
+	suffix := resource + "/" + strconv.Itoa(id) + "/"
+	if !strings.HasSuffix(url, suffix) {
+		return ""
+	}
+	return strings.TrimSuffix(url, suffix)
+}
+
+// refID returns the ID of a resource from its URL, such as 1 in
+// https://pokeapi.co/api/v2/pokemon/1/.
+func refID(ref models.NamedApiResource) (int, error) {
+	segment := path.Base(strings.TrimSuffix(ref.Url, "/"))
+	id, err := strconv.Atoi(segment)
+	if err != nil {
+		return 0, errors.New("no ID in URL of " + ref.Name + ": " + ref.Url)
+	}
+	return id, nil
+}
+
+func writeJSON(name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(name, append(b, '\n'))
+}
+
+func writeFile(name string, b []byte) error {
+	err := os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0o644)
+}
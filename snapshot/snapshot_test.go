@@ -0,0 +1,84 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdcurran/pokedex"
+	"github.com/mdcurran/pokedex/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+
+	names := []string{"hardy", "bold", "modest"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nature" {
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			list := models.NamedApiResourceList{Count: len(names), Results: []models.NamedApiResource{}}
+			for i := offset; i < offset+limit && i < len(names); i++ {
+				list.Results = append(list.Results, models.NamedApiResource{
+					Name: names[i],
+					Url:  fmt.Sprintf("http://%s/nature/%d/", r.Host, i+1),
+				})
+			}
+			json.NewEncoder(w).Encode(list)
+			return
+		}
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/nature/"))
+		fmt.Fprintf(w, `{"id": %d, "name": %q, "url": "http://%s/nature/%d/"}`, id, names[id-1], r.Host, id)
+	}))
+	t.Cleanup(srv.Close)
+
+	sdk, err := pokedex.NewWithOptions(pokedex.Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	dir := t.TempDir()
+	err = ExportWithOptions(ctx, sdk, dir, Options{PageSize: 2}, "nature")
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(dir, "api/v2/nature/2/index.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": 2, "name": "bold", "url": "/api/v2/nature/2/"}`, string(b))
+
+	b, err = os.ReadFile(filepath.Join(dir, "api/v2/nature/index.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"count": 3, "next": null, "previous": null, "results": [
+			{"name": "hardy", "url": "/api/v2/nature/1/"},
+			{"name": "bold", "url": "/api/v2/nature/2/"},
+			{"name": "modest", "url": "/api/v2/nature/3/"}
+		]
+	}`, string(b))
+
+	// The snapshot can be served by an offline client.
+	offline, err := pokedex.NewWithOptions(pokedex.Options{
+		BaseURL:          "https://pokeapi.co/api/v2",
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		OfflineDir:       dir,
+	})
+	require.NoError(t, err)
+	t.Cleanup(offline.Close)
+
+	res, err := offline.GetNature(ctx, pokedex.GetRequest{Name: "modest"})
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Nature.ID)
+}
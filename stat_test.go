@@ -9,7 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/mdcurran/pokedex/internal/faker"
+	"github.com/mdcurran/pokedex/faker"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,5 +40,5 @@ func TestGetStat(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, string(fixture), string(serialised))
 
-	require.Len(t, res.Stat.Characteristics, 3)
+	require.Equal(t, res.Stat.IsBattleOnly, len(res.Stat.Characteristics) == 0)
 }
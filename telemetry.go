@@ -0,0 +1,70 @@
+package pokedex
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName identifies the SDK as the source of its spans and
+// metrics.
+const instrumentationName = "github.com/mdcurran/pokedex"
+
+// telemetry holds the OpenTelemetry instruments used by the SDK client. If no
+// providers are configured every instrument is a no-op.
+type telemetry struct {
+	tracer trace.Tracer
+
+	requestDuration  metric.Float64Histogram
+	requestsInFlight metric.Int64UpDownCounter
+	// The cache hit ratio can be derived from the number of hits and misses.
+	cacheHits   metric.Int64Counter
+	cacheMisses metric.Int64Counter
+}
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) (*telemetry, error) {
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+
+	var (
+		t     = &telemetry{tracer: tp.Tracer(instrumentationName)}
+		meter = mp.Meter(instrumentationName)
+		err   error
+	)
+
+	t.requestDuration, err = meter.Float64Histogram("pokedex.client.request.duration",
+		metric.WithDescription("Duration of HTTP requests made to the PokéAPI."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.requestsInFlight, err = meter.Int64UpDownCounter("pokedex.client.requests.in_flight",
+		metric.WithDescription("Number of HTTP requests to the PokéAPI currently in flight."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.cacheHits, err = meter.Int64Counter("pokedex.cache.hits",
+		metric.WithDescription("Number of responses served from the cache."),
+		metric.WithUnit("{hit}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.cacheMisses, err = meter.Int64Counter("pokedex.cache.misses",
+		metric.WithDescription("Number of responses not found in the cache."),
+		metric.WithUnit("{miss}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,94 @@
+package pokedex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stat":
+			fmt.Fprint(w, `{"count": 2, "next": null, "previous": null, "results": [
+				{"name": "hp", "url": ""},
+				{"name": "missing", "url": ""}
+			]}`)
+		case "/stat/hp":
+			fmt.Fprint(w, `{"id": 1, "name": "hp"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		spans  = tracetest.NewSpanRecorder()
+		reader = sdkmetric.NewManualReader()
+	)
+	sdk, err := NewWithOptions(Options{
+		BaseURL:          srv.URL,
+		Timeout:          5 * time.Second,
+		CacheMaximumSize: 1 << 20,
+		CacheTTL:         10 * time.Second,
+		TracerProvider:   sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:    sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdk.Close)
+
+	_, err = sdk.GetStat(ctx, GetRequest{Name: "hp"})
+	require.NoError(t, err)
+
+	res, err := sdk.ListStats(ctx, ListRequest{PageSize: 2})
+	require.NoError(t, err)
+	_, err = res.Iterator.Next(ctx)
+	require.Error(t, err)
+
+	counts := map[string]int{}
+	for _, span := range spans.Ended() {
+		counts[span.Name()]++
+		if span.Name() == "pokedex.list.page" {
+			require.Equal(t, codes.Error, span.Status().Code)
+		}
+	}
+	// One fetch for the Stat, and one each for the page and its two results.
+	require.Equal(t, map[string]int{
+		"pokedex.fetch":     4,
+		"pokedex.cache.get": 4,
+		"pokedex.list.page": 1,
+	}, counts)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	// The second request for "hp" is served from the cache.
+	require.Equal(t, int64(1), metrics["pokedex.cache.hits"].(metricdata.Sum[int64]).DataPoints[0].Value)
+	require.Equal(t, int64(3), metrics["pokedex.cache.misses"].(metricdata.Sum[int64]).DataPoints[0].Value)
+	require.Equal(t, int64(0), metrics["pokedex.client.requests.in_flight"].(metricdata.Sum[int64]).DataPoints[0].Value)
+
+	var requests uint64
+	for _, dp := range metrics["pokedex.client.request.duration"].(metricdata.Histogram[float64]).DataPoints {
+		requests += dp.Count
+	}
+	require.Equal(t, uint64(3), requests)
+}